@@ -0,0 +1,117 @@
+package tavo
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"time"
+)
+
+// AIAnalysisOperations groups the /ai endpoints.
+type AIAnalysisOperations struct {
+	client *Client
+}
+
+// AnalyzeCode submits code for AI analysis and waits for the result.
+func (a *AIAnalysisOperations) AnalyzeCode(ctx context.Context, codeData map[string]interface{}) (map[string]interface{}, error) {
+	return a.client.makeRequest(ctx, http.MethodPost, "/ai/analyze", codeData, nil)
+}
+
+// AnalyzeCodeFromReader is AnalyzeCode with the JSON request read from r
+// and streamed to the server, for payloads with large file contents. The
+// request is not retried, and fails with ErrBodyTooLarge past
+// Config.MaxBodyBytes.
+func (a *AIAnalysisOperations) AnalyzeCodeFromReader(ctx context.Context, r io.Reader) (map[string]interface{}, error) {
+	return a.client.makeRequest(ctx, http.MethodPost, "/ai/analyze", r, nil)
+}
+
+// GetAnalysis fetches an analysis by ID.
+func (a *AIAnalysisOperations) GetAnalysis(ctx context.Context, analysisID string) (map[string]interface{}, error) {
+	return a.client.makeRequest(ctx, http.MethodGet, "/ai/analyses/"+analysisID, nil, nil)
+}
+
+// GetAnalysisResults fetches an analysis's status and findings.
+func (a *AIAnalysisOperations) GetAnalysisResults(ctx context.Context, analysisID string) (map[string]interface{}, error) {
+	return a.client.makeRequest(ctx, http.MethodGet, "/ai/analyses/"+analysisID+"/results", nil, nil)
+}
+
+// ListAnalyses lists analyses.
+func (a *AIAnalysisOperations) ListAnalyses(ctx context.Context, params map[string]interface{}) (map[string]interface{}, error) {
+	return a.client.makeRequest(ctx, http.MethodGet, "/ai/analyses", nil, params)
+}
+
+// AnalysisFilter narrows the analyses returned by ListAnalysesTyped and
+// IterateAnalyses. Zero values are not sent.
+type AnalysisFilter struct {
+	Status       string
+	Language     string
+	CreatedAfter time.Time
+	Limit        int
+	Offset       int
+}
+
+func (f AnalysisFilter) params() map[string]interface{} {
+	params := map[string]interface{}{}
+	if f.Status != "" {
+		params["status"] = f.Status
+	}
+	if f.Language != "" {
+		params["language"] = f.Language
+	}
+	if !f.CreatedAfter.IsZero() {
+		params["created_after"] = f.CreatedAfter
+	}
+	if f.Limit > 0 {
+		params["limit"] = f.Limit
+	}
+	if f.Offset > 0 {
+		params["offset"] = f.Offset
+	}
+	return params
+}
+
+// ListAnalysesTyped lists one page of analyses matching filter.
+func (a *AIAnalysisOperations) ListAnalysesTyped(ctx context.Context, filter AnalysisFilter) (map[string]interface{}, error) {
+	return a.ListAnalyses(ctx, filter.params())
+}
+
+// IterateAnalyses walks every analysis matching filter. The filter's Offset
+// is the starting point and its Limit the page size.
+func (a *AIAnalysisOperations) IterateAnalyses(ctx context.Context, filter AnalysisFilter) *Iterator[map[string]interface{}] {
+	if filter.Limit <= 0 {
+		filter.Limit = DefaultPageSize
+	}
+	start := filter.Offset
+	return newIterator(ctx, func(ctx context.Context, offset int) ([]map[string]interface{}, int, error) {
+		f := filter
+		f.Offset = start + offset
+		resp, err := a.ListAnalysesTyped(ctx, f)
+		if err != nil {
+			return nil, 0, err
+		}
+		items, total := pageItems(resp, f.Offset)
+		return items, total - start, nil
+	})
+}
+
+// WaitForAnalysis polls GetAnalysisResults every pollInterval
+// (DefaultPollInterval when zero or negative) until the analysis completes,
+// fails or is cancelled, and returns the final results. Analyses report the
+// same status values as scans.
+func (a *AIAnalysisOperations) WaitForAnalysis(ctx context.Context, analysisID string, pollInterval time.Duration) (map[string]interface{}, error) {
+	if pollInterval <= 0 {
+		pollInterval = DefaultPollInterval
+	}
+	for {
+		results, err := a.GetAnalysisResults(ctx, analysisID)
+		if err != nil {
+			return nil, err
+		}
+		if isTerminalScanStatus(results["status"]) {
+			return results, nil
+		}
+		if err := sleepContext(ctx, pollInterval); err != nil {
+			return nil, err
+		}
+	}
+}
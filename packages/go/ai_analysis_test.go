@@ -0,0 +1,140 @@
+package tavo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestIterateAnalysesSendsFilter(t *testing.T) {
+	c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("status") != "completed" || q.Get("language") != "go" || q.Get("limit") != "2" {
+			t.Errorf("query = %s", r.URL.RawQuery)
+		}
+		if q.Get("created_after") != "2026-01-02T00:00:00Z" {
+			t.Errorf("created_after = %q", q.Get("created_after"))
+		}
+		var items []map[string]interface{}
+		switch q.Get("offset") {
+		case "1":
+			items = []map[string]interface{}{{"id": "b"}, {"id": "c"}}
+		case "3":
+			items = []map[string]interface{}{{"id": "d"}}
+		default:
+			t.Errorf("offset = %q", q.Get("offset"))
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{"items": items, "total": 4})
+	})
+
+	it := c.AI().IterateAnalyses(context.Background(), AnalysisFilter{
+		Status:       "completed",
+		Language:     "go",
+		CreatedAfter: time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC),
+		Limit:        2,
+		Offset:       1,
+	})
+	var ids []string
+	for it.Next() {
+		ids = append(ids, it.Item()["id"].(string))
+	}
+	if err := it.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if len(ids) != 3 || ids[0] != "b" || ids[2] != "d" {
+		t.Fatalf("ids = %v", ids)
+	}
+}
+
+func TestWaitForAnalysis(t *testing.T) {
+	polls := 0
+	c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/ai/analyses/a1/results" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		polls++
+		status := "running"
+		if polls == 3 {
+			status = "completed"
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{"status": status, "findings": []interface{}{}})
+	})
+
+	res, err := c.AI().WaitForAnalysis(context.Background(), "a1", time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res["status"] != "completed" || polls != 3 {
+		t.Fatalf("status = %v after %d polls", res["status"], polls)
+	}
+}
+
+func TestWaitForAnalysisZeroInterval(t *testing.T) {
+	polls := 0
+	c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		polls++
+		writeJSON(w, http.StatusOK, map[string]interface{}{"status": "running"})
+	})
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	_, err := c.AI().WaitForAnalysis(ctx, "a1", 0)
+	if !errors.Is(err, context.DeadlineExceeded) || polls != 1 {
+		t.Fatalf("err = %v, polls = %d; want one poll per DefaultPollInterval", err, polls)
+	}
+}
+
+func TestAnalyzeCodeTypedInfersLanguages(t *testing.T) {
+	var got AnalyzeCodeRequest
+	c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/ai/analyze" {
+			t.Errorf("unexpected %s %s", r.Method, r.URL.Path)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Error(err)
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{"status": "completed"})
+	})
+
+	req := AnalyzeCodeRequest{
+		Ruleset: "owasp",
+		Files: []CodeFile{
+			{Path: "main.go", Content: "package main"},
+			{Path: "web/App.TSX", Content: "export {}"},
+			{Path: "Makefile", Content: "all:"},
+			{Path: "lib.py", Content: "x = 1", Language: "python3"},
+		},
+	}
+	if _, err := c.AI().AnalyzeCodeTyped(context.Background(), req); err != nil {
+		t.Fatal(err)
+	}
+	var langs []string
+	for _, f := range got.Files {
+		langs = append(langs, f.Language)
+	}
+	if strings.Join(langs, ",") != "go,typescript,,python3" || got.Ruleset != "owasp" {
+		t.Fatalf("sent %+v", got)
+	}
+	if req.Files[0].Language != "" {
+		t.Fatal("caller's request was modified")
+	}
+}
+
+func TestAnalyzeCodeTypedValidates(t *testing.T) {
+	c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Error("request sent for an invalid analysis")
+	})
+	c.config.MaxAnalyzeBytes = 10
+	ctx := context.Background()
+
+	if _, err := c.AI().AnalyzeCodeTyped(ctx, AnalyzeCodeRequest{}); err == nil {
+		t.Error("empty file list accepted")
+	}
+	big := AnalyzeCodeRequest{Files: []CodeFile{{Path: "a.go", Content: "123456"}, {Path: "b.go", Content: "7890X"}}}
+	if _, err := c.AI().AnalyzeCodeTyped(ctx, big); err == nil || !strings.Contains(err.Error(), "11 bytes") {
+		t.Errorf("err = %v", err)
+	}
+}
@@ -0,0 +1,98 @@
+package tavo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"strings"
+)
+
+// DefaultMaxAnalyzeBytes caps the total file content AnalyzeCodeTyped
+// sends when Config.MaxAnalyzeBytes is zero.
+const DefaultMaxAnalyzeBytes = 5 << 20
+
+// AnalyzeCodeRequest is the input of AnalyzeCodeTyped.
+type AnalyzeCodeRequest struct {
+	Files []CodeFile `json:"files"`
+	// Language applies to every file. When empty, each file's language is
+	// inferred from its extension.
+	Language string `json:"language,omitempty"`
+	Ruleset  string `json:"ruleset,omitempty"`
+}
+
+// CodeFile is one source file submitted for analysis.
+type CodeFile struct {
+	Path    string `json:"path"`
+	Content string `json:"content"`
+	// Language is filled in from the extension when neither it nor the
+	// request's Language is set.
+	Language string `json:"language,omitempty"`
+}
+
+// languagesByExt maps file extensions to the language names the API uses.
+var languagesByExt = map[string]string{
+	".go":    "go",
+	".py":    "python",
+	".js":    "javascript",
+	".jsx":   "javascript",
+	".mjs":   "javascript",
+	".ts":    "typescript",
+	".tsx":   "typescript",
+	".java":  "java",
+	".kt":    "kotlin",
+	".rb":    "ruby",
+	".php":   "php",
+	".cs":    "csharp",
+	".rs":    "rust",
+	".c":     "c",
+	".h":     "c",
+	".cpp":   "cpp",
+	".cc":    "cpp",
+	".hpp":   "cpp",
+	".swift": "swift",
+	".scala": "scala",
+	".sh":    "shell",
+	".sql":   "sql",
+	".yaml":  "yaml",
+	".yml":   "yaml",
+	".tf":    "terraform",
+}
+
+// DetectLanguage returns the language for path's extension, or "" when it
+// is not recognized.
+func DetectLanguage(path string) string {
+	return languagesByExt[strings.ToLower(filepath.Ext(path))]
+}
+
+// AnalyzeCodeTyped submits files for AI analysis and waits for the result.
+// At least one file is required, and the combined content must not exceed
+// Config.MaxAnalyzeBytes (DefaultMaxAnalyzeBytes when zero). The caller's
+// request is not modified.
+func (a *AIAnalysisOperations) AnalyzeCodeTyped(ctx context.Context, req AnalyzeCodeRequest) (map[string]interface{}, error) {
+	if len(req.Files) == 0 {
+		return nil, errors.New("tavo: analyze request needs at least one file")
+	}
+	limit := a.client.config.MaxAnalyzeBytes
+	if limit <= 0 {
+		limit = DefaultMaxAnalyzeBytes
+	}
+	files := make([]CodeFile, len(req.Files))
+	total := 0
+	for i, f := range req.Files {
+		if f.Path == "" {
+			return nil, fmt.Errorf("tavo: analyze request file %d has no path", i)
+		}
+		total += len(f.Content)
+		if req.Language == "" && f.Language == "" {
+			f.Language = DetectLanguage(f.Path)
+		}
+		files[i] = f
+	}
+	if total > limit {
+		return nil, fmt.Errorf("tavo: analyze request content is %d bytes, over the %d byte limit", total, limit)
+	}
+	req.Files = files
+	return a.client.makeRequest(ctx, http.MethodPost, "/ai/analyze", req, nil)
+}
@@ -0,0 +1,177 @@
+package tavo
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// Analysis stream event types.
+const (
+	AnalysisEventProgress = "progress"
+	AnalysisEventFinding  = "finding"
+	AnalysisEventComplete = "complete"
+	AnalysisEventError    = "error"
+)
+
+// AnalysisEvent is one event of an analysis stream.
+type AnalysisEvent struct {
+	// ID is the server-sent event ID used to resume after a dropped
+	// connection.
+	ID   string `json:"-"`
+	Type string `json:"type"`
+	// Finding is set on "finding" events.
+	Finding *Finding `json:"finding,omitempty"`
+	// Progress is the fraction of the analysis done, from 0 to 1.
+	Progress float64 `json:"progress,omitempty"`
+	// Message describes "error" events.
+	Message string `json:"message,omitempty"`
+	// Data is the raw event payload.
+	Data json.RawMessage `json:"-"`
+}
+
+// AnalyzeCodeStream submits code for analysis and sends events to events as
+// the server produces them, ending with a "complete" event. The channel is
+// closed when the function returns. If the connection drops before the
+// analysis completes, the request is re-sent with Last-Event-ID so the
+// server can resume, up to MaxRetries times. An "error" event ends the
+// stream with a TavoError.
+func (a *AIAnalysisOperations) AnalyzeCodeStream(ctx context.Context, codeData map[string]interface{}, events chan<- AnalysisEvent) error {
+	defer close(events)
+
+	var lastID string
+	var lastErr error
+	for attempt := 0; attempt <= a.client.config.MaxRetries; attempt++ {
+		if attempt > 0 {
+			wait := a.client.config.RetryWait << (attempt - 1)
+			a.client.logf("tavo: reconnecting analysis stream (attempt %d): %v", attempt, lastErr)
+			a.client.notifyRetry(attempt, http.MethodPost, "/ai/analyze", wait, nil, lastErr)
+			if err := sleepContext(ctx, wait); err != nil {
+				return err
+			}
+		}
+		done, err := a.streamOnce(ctx, codeData, lastID, events, &lastID)
+		if done || err == nil {
+			return err
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		var tErr *TavoError
+		if errors.As(err, &tErr) && !isRetryableStatus(tErr.StatusCode) {
+			return err
+		}
+		lastErr = err
+	}
+	return fmt.Errorf("tavo: analysis stream: %w", lastErr)
+}
+
+// streamOnce reads one connection's worth of events. done is true when the
+// stream reached a terminal event, in which case err is the final result;
+// otherwise err says why the connection ended early.
+func (a *AIAnalysisOperations) streamOnce(ctx context.Context, codeData map[string]interface{}, resumeID string, events chan<- AnalysisEvent, lastID *string) (done bool, err error) {
+	r := a.client.http.R().
+		SetContext(ctx).
+		SetHeader("Accept", "text/event-stream").
+		SetHeader("Content-Type", "application/json").
+		SetQueryParam("stream", "true").
+		SetBody(codeData).
+		SetDoNotParseResponse(true)
+	if resumeID != "" {
+		r.SetHeader("Last-Event-ID", resumeID)
+	}
+	if err := a.client.applyCredentials(r); err != nil {
+		return true, err
+	}
+	req := &apiRequest{method: http.MethodPost, path: "/ai/analyze"}
+	resp, err := a.client.sendAttempt(ctx, req, 0, func() (*resty.Response, error) { return r.Post(req.path) })
+	if te, ok := err.(*TavoError); ok {
+		return true, te
+	}
+	if err != nil {
+		return false, fmt.Errorf("tavo: POST /ai/analyze: %w", err)
+	}
+	if err := decodeContentEncoding(resp.RawResponse); err != nil {
+		return false, fmt.Errorf("tavo: POST /ai/analyze: %w", err)
+	}
+	body := resp.RawBody()
+	defer body.Close()
+	if status := resp.StatusCode(); status < 200 || status > 299 {
+		data, _ := io.ReadAll(body)
+		return false, responseError(status, resp.Header(), data)
+	}
+
+	var (
+		id, eventType string
+		data          strings.Builder
+	)
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(make([]byte, 64*1024), 4*1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line != "" {
+			field, value, _ := strings.Cut(line, ":")
+			value = strings.TrimPrefix(value, " ")
+			switch field {
+			case "id":
+				id = value
+			case "event":
+				eventType = value
+			case "data":
+				if data.Len() > 0 {
+					data.WriteByte('\n')
+				}
+				data.WriteString(value)
+			}
+			continue
+		}
+
+		// A blank line dispatches the event.
+		if data.Len() == 0 {
+			eventType = ""
+			continue
+		}
+		ev, err := parseAnalysisEvent(id, eventType, data.String())
+		data.Reset()
+		eventType = ""
+		if err != nil {
+			return true, err
+		}
+		if id != "" {
+			*lastID = id
+		}
+		select {
+		case events <- ev:
+		case <-ctx.Done():
+			return true, ctx.Err()
+		}
+		switch ev.Type {
+		case AnalysisEventComplete:
+			return true, nil
+		case AnalysisEventError:
+			return true, &TavoError{StatusCode: resp.StatusCode(), Code: "analysis_failed", Message: ev.Message}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return false, fmt.Errorf("tavo: reading analysis stream: %w", err)
+	}
+	return false, io.ErrUnexpectedEOF
+}
+
+func parseAnalysisEvent(id, eventType, data string) (AnalysisEvent, error) {
+	ev := AnalysisEvent{ID: id, Data: json.RawMessage(data)}
+	if err := json.Unmarshal([]byte(data), &ev); err != nil {
+		return ev, fmt.Errorf("tavo: decoding analysis event: %w", err)
+	}
+	if eventType != "" {
+		ev.Type = eventType
+	}
+	return ev, nil
+}
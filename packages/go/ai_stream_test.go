@@ -0,0 +1,83 @@
+package tavo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestAnalyzeCodeStreamResumesAfterDrop(t *testing.T) {
+	connections := 0
+	c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("stream") != "true" || r.Header.Get("Accept") != "text/event-stream" {
+			t.Errorf("not a stream request: %s %v", r.URL, r.Header)
+		}
+		connections++
+		w.Header().Set("Content-Type", "text/event-stream")
+		if connections == 1 {
+			fmt.Fprint(w, "id: 1\nevent: progress\ndata: {\"progress\":0.5}\n\n")
+			fmt.Fprint(w, "id: 2\ndata: {\"type\":\"finding\",\n")
+			fmt.Fprint(w, "data: \"finding\":{\"rule_id\":\"R1\",\"file\":\"a.go\",\"line\":4}}\n\n")
+			return // drop before completion
+		}
+		if got := r.Header.Get("Last-Event-ID"); got != "2" {
+			t.Errorf("Last-Event-ID = %q", got)
+		}
+		fmt.Fprint(w, ": keep-alive\n\n")
+		fmt.Fprint(w, "id: 3\ndata: {\"type\":\"complete\",\"progress\":1}\n\n")
+	})
+
+	events := make(chan AnalysisEvent, 10)
+	if err := c.AI().AnalyzeCodeStream(context.Background(), map[string]interface{}{"code": "x"}, events); err != nil {
+		t.Fatal(err)
+	}
+	var got []AnalysisEvent
+	for ev := range events {
+		got = append(got, ev)
+	}
+	if len(got) != 3 || connections != 2 {
+		t.Fatalf("events = %+v, connections = %d", got, connections)
+	}
+	if got[0].Type != AnalysisEventProgress || got[0].Progress != 0.5 {
+		t.Fatalf("event 0 = %+v", got[0])
+	}
+	if got[1].Finding == nil || got[1].Finding.RuleID != "R1" || got[1].ID != "2" {
+		t.Fatalf("event 1 = %+v", got[1])
+	}
+	if got[2].Type != AnalysisEventComplete {
+		t.Fatalf("event 2 = %+v", got[2])
+	}
+}
+
+func TestAnalyzeCodeStreamErrorEvent(t *testing.T) {
+	c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "data: {\"type\":\"error\",\"message\":\"model unavailable\"}\n\n")
+	})
+	events := make(chan AnalysisEvent, 1)
+	err := c.AI().AnalyzeCodeStream(context.Background(), nil, events)
+	var tErr *TavoError
+	if !errors.As(err, &tErr) || tErr.Message != "model unavailable" {
+		t.Fatalf("err = %v", err)
+	}
+	if _, open := <-events; !open {
+		t.Fatal("error event not delivered")
+	}
+	if _, open := <-events; open {
+		t.Fatal("channel not closed")
+	}
+}
+
+func TestAnalyzeCodeStreamClientError(t *testing.T) {
+	calls := 0
+	c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		writeJSON(w, http.StatusBadRequest, map[string]interface{}{"message": "no code"})
+	})
+	err := c.AI().AnalyzeCodeStream(context.Background(), nil, make(chan AnalysisEvent))
+	var tErr *TavoError
+	if !errors.As(err, &tErr) || tErr.StatusCode != http.StatusBadRequest || calls != 1 {
+		t.Fatalf("err = %v, calls = %d", err, calls)
+	}
+}
@@ -0,0 +1,73 @@
+package tavo
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Annotation states recorded by AnnotateFinding.
+const (
+	AnnotationOpen          = "open"
+	AnnotationFalsePositive = "false_positive"
+	AnnotationAccepted      = "accepted"
+	AnnotationWontFix       = "wont_fix"
+)
+
+// AnnotationStates lists the valid Annotation states.
+var AnnotationStates = []string{AnnotationOpen, AnnotationFalsePositive, AnnotationAccepted, AnnotationWontFix}
+
+// Annotation is a triage decision about a finding.
+type Annotation struct {
+	State string `json:"state"`
+	Note  string `json:"note,omitempty"`
+	// ExpiresAt, when set, is when the decision lapses and the finding
+	// returns to open, for example for a time-boxed accepted risk.
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+// FindingAnnotation is an Annotation as stored for one finding.
+type FindingAnnotation struct {
+	Annotation
+	FindingID string    `json:"finding_id"`
+	CreatedBy string    `json:"created_by,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// AnnotateFinding records a triage decision about a finding. The state is
+// checked against AnnotationStates before anything is sent.
+func (s *ScanOperations) AnnotateFinding(ctx context.Context, scanID, findingID string, annotation Annotation) error {
+	if !validAnnotationState(annotation.State) {
+		return fmt.Errorf("tavo: unknown annotation state %q (want one of %v)", annotation.State, AnnotationStates)
+	}
+	_, err := s.client.makeRequest(ctx, http.MethodPost, "/scans/"+scanID+"/findings/"+findingID+"/annotations", annotation, nil)
+	return err
+}
+
+// ListAnnotations lists the annotations of every finding in a scan.
+func (s *ScanOperations) ListAnnotations(ctx context.Context, scanID string) ([]FindingAnnotation, error) {
+	resp, err := s.client.makeRequest(ctx, http.MethodGet, "/scans/"+scanID+"/annotations", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	items, _ := pageItems(resp, 0)
+	annotations := make([]FindingAnnotation, 0, len(items))
+	for _, item := range items {
+		var a FindingAnnotation
+		if err := decodeMap(item, &a); err != nil {
+			return nil, err
+		}
+		annotations = append(annotations, a)
+	}
+	return annotations, nil
+}
+
+func validAnnotationState(state string) bool {
+	for _, s := range AnnotationStates {
+		if state == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,63 @@
+package tavo
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestAnnotateFinding(t *testing.T) {
+	var sent map[string]interface{}
+	c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "POST /scans/s1/findings/f1/annotations":
+			json.NewDecoder(r.Body).Decode(&sent)
+			writeJSON(w, http.StatusCreated, map[string]interface{}{"id": "a1"})
+		case "GET /scans/s1/annotations":
+			writeJSON(w, http.StatusOK, map[string]interface{}{"items": []interface{}{
+				map[string]interface{}{
+					"finding_id": "f1", "state": "accepted", "note": "tracked in SEC-12",
+					"expires_at": "2026-01-01T00:00:00Z", "created_by": "u1", "created_at": "2025-06-01T00:00:00Z",
+				},
+				map[string]interface{}{"finding_id": "f2", "state": "false_positive", "created_at": "2025-06-02T00:00:00Z"},
+			}})
+		default:
+			t.Errorf("unexpected %s %s", r.Method, r.URL.Path)
+		}
+	})
+	ctx := context.Background()
+
+	expires := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	err := c.Scans().AnnotateFinding(ctx, "s1", "f1", Annotation{State: AnnotationAccepted, Note: "tracked in SEC-12", ExpiresAt: &expires})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sent["state"] != "accepted" || sent["note"] != "tracked in SEC-12" || sent["expires_at"] != "2026-01-01T00:00:00Z" {
+		t.Fatalf("sent %v", sent)
+	}
+
+	list, err := c.Scans().ListAnnotations(ctx, "s1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list) != 2 {
+		t.Fatalf("got %d annotations", len(list))
+	}
+	if a := list[0]; a.FindingID != "f1" || a.State != AnnotationAccepted || a.ExpiresAt == nil || !a.ExpiresAt.Equal(expires) || a.CreatedBy != "u1" {
+		t.Fatalf("first = %+v", a)
+	}
+	if a := list[1]; a.State != AnnotationFalsePositive || a.ExpiresAt != nil {
+		t.Fatalf("second = %+v", a)
+	}
+}
+
+func TestAnnotateFindingRejectsUnknownState(t *testing.T) {
+	c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Error("request sent for an invalid state")
+	})
+	if err := c.Scans().AnnotateFinding(context.Background(), "s1", "f1", Annotation{State: "ignored"}); err == nil {
+		t.Fatal("expected an error")
+	}
+}
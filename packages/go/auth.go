@@ -0,0 +1,164 @@
+package tavo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+	"unicode/utf8"
+)
+
+// MinPasswordLength is the shortest password the API accepts. Passwords
+// are checked against it before a request is sent.
+const MinPasswordLength = 8
+
+// AuthOperations groups the /auth endpoints.
+type AuthOperations struct {
+	client *Client
+}
+
+// Login exchanges credentials for an access token.
+func (a *AuthOperations) Login(ctx context.Context, email, password string) (map[string]interface{}, error) {
+	data := map[string]interface{}{"email": email, "password": password}
+	return a.client.makeRequest(ctx, http.MethodPost, "/auth/login", data, nil)
+}
+
+// AuthResult is the outcome of a login step. When MFARequired is set the
+// login is not complete: Token is empty, and ChallengeToken must be passed
+// to LoginMFA together with the user's TOTP code.
+type AuthResult struct {
+	Token          string    `json:"access_token"`
+	RefreshToken   string    `json:"refresh_token,omitempty"`
+	ExpiresAt      time.Time `json:"expires_at,omitempty"`
+	MFARequired    bool      `json:"mfa_required,omitempty"`
+	ChallengeToken string    `json:"challenge_token,omitempty"`
+}
+
+// LoginTyped is Login returning an AuthResult, for two-step logins: if
+// the account requires MFA the result has MFARequired set and a
+// ChallengeToken instead of a token. Both a 200 response and an error
+// response with code "mfa_required" are treated this way.
+func (a *AuthOperations) LoginTyped(ctx context.Context, email, password string) (*AuthResult, error) {
+	resp, err := a.Login(ctx, email, password)
+	if err != nil {
+		var te *TavoError
+		if errors.As(err, &te) && te.Code == "mfa_required" {
+			challenge, _ := te.Details["challenge_token"].(string)
+			return &AuthResult{MFARequired: true, ChallengeToken: challenge}, nil
+		}
+		return nil, err
+	}
+	return decodeAuthResult(resp)
+}
+
+// LoginMFA completes a login that returned MFARequired, using its
+// ChallengeToken and a TOTP code. It is never retried, since a code is
+// only valid once.
+func (a *AuthOperations) LoginMFA(ctx context.Context, challengeToken, totpCode string) (*AuthResult, error) {
+	data := map[string]interface{}{"challenge_token": challengeToken, "code": totpCode}
+	resp, err := a.client.execute(ctx, &apiRequest{method: http.MethodPost, path: "/auth/login/mfa", body: data, noRetry: true})
+	if err != nil {
+		return nil, err
+	}
+	body, err := checkResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+	return decodeAuthResult(body)
+}
+
+// decodeAuthResult decodes a login response. Servers that send a relative
+// "expires_in" in seconds instead of "expires_at" get it converted.
+func decodeAuthResult(resp map[string]interface{}) (*AuthResult, error) {
+	var res AuthResult
+	if err := decodeMap(resp, &res); err != nil {
+		return nil, err
+	}
+	if secs, ok := resp["expires_in"].(float64); ok && res.ExpiresAt.IsZero() {
+		res.ExpiresAt = time.Now().Add(time.Duration(secs * float64(time.Second)))
+	}
+	return &res, nil
+}
+
+// Register creates a new account.
+func (a *AuthOperations) Register(ctx context.Context, userData map[string]interface{}) (map[string]interface{}, error) {
+	return a.client.makeRequest(ctx, http.MethodPost, "/auth/register", userData, nil)
+}
+
+// RefreshToken exchanges a refresh token for a new access token.
+func (a *AuthOperations) RefreshToken(ctx context.Context, refreshToken string) (map[string]interface{}, error) {
+	data := map[string]interface{}{"refresh_token": refreshToken}
+	return a.client.makeRequest(ctx, http.MethodPost, "/auth/refresh", data, nil)
+}
+
+// Logout invalidates the current session or token server-side. When the
+// client authenticates with a session token, it is cleared so later
+// requests are not sent with a dead session.
+func (a *AuthOperations) Logout(ctx context.Context) error {
+	if _, err := a.client.makeRequest(ctx, http.MethodPost, "/auth/logout", nil, nil); err != nil {
+		return err
+	}
+	if s := a.client.session; s != nil {
+		s.set("")
+	}
+	a.client.InvalidateUserCache()
+	return nil
+}
+
+// RefreshSession extends the current session. If the server issues a new
+// "session_token", the client switches to it for later requests. Use
+// RefreshToken for JWT-based auth instead.
+func (a *AuthOperations) RefreshSession(ctx context.Context) (map[string]interface{}, error) {
+	resp, err := a.client.makeRequest(ctx, http.MethodPost, "/auth/session/refresh", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	if token, ok := resp["session_token"].(string); ok && token != "" && a.client.session != nil {
+		a.client.session.set(token)
+	}
+	return resp, nil
+}
+
+// ChangePassword changes the authenticated user's password.
+func (a *AuthOperations) ChangePassword(ctx context.Context, oldPassword, newPassword string) error {
+	if err := checkPassword(newPassword); err != nil {
+		return err
+	}
+	data := map[string]interface{}{"old_password": oldPassword, "new_password": newPassword}
+	return a.sendOnce(ctx, "/auth/change-password", data)
+}
+
+// RequestPasswordReset emails a password reset token to email. The API
+// answers the same way whether or not the account exists.
+func (a *AuthOperations) RequestPasswordReset(ctx context.Context, email string) error {
+	return a.sendOnce(ctx, "/auth/reset-request", map[string]interface{}{"email": email})
+}
+
+// ConfirmPasswordReset sets a new password using a token from the reset
+// email.
+func (a *AuthOperations) ConfirmPasswordReset(ctx context.Context, token, newPassword string) error {
+	if err := checkPassword(newPassword); err != nil {
+		return err
+	}
+	data := map[string]interface{}{"token": token, "new_password": newPassword}
+	return a.sendOnce(ctx, "/auth/reset-confirm", data)
+}
+
+// sendOnce POSTs data to path without retrying: a retried password change
+// or reset request could be applied, or emailed, twice.
+func (a *AuthOperations) sendOnce(ctx context.Context, path string, data map[string]interface{}) error {
+	resp, err := a.client.execute(ctx, &apiRequest{method: http.MethodPost, path: path, body: data, noRetry: true})
+	if err != nil {
+		return err
+	}
+	_, err = checkResponse(resp)
+	return err
+}
+
+func checkPassword(password string) error {
+	if utf8.RuneCountInString(password) < MinPasswordLength {
+		return fmt.Errorf("tavo: password must be at least %d characters", MinPasswordLength)
+	}
+	return nil
+}
@@ -0,0 +1,182 @@
+package tavo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestSessionTokenLifecycle(t *testing.T) {
+	var seen []string
+	srv := httptest.NewServer(apiHandler(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-API-Key") != "" {
+			t.Error("API key sent alongside session token")
+		}
+		seen = append(seen, r.URL.Path+" "+r.Header.Get("X-Session-Token"))
+		switch r.URL.Path {
+		case "/auth/session/refresh":
+			writeJSON(w, http.StatusOK, map[string]interface{}{"session_token": "sess-2", "expires_in": 3600})
+		case "/auth/logout":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			writeJSON(w, http.StatusOK, map[string]interface{}{})
+		}
+	}))
+	defer srv.Close()
+	c := newTestClientFor(t, srv, func(cfg *Config) { cfg.WithSessionToken("sess-1") })
+	ctx := context.Background()
+
+	if _, err := c.Users().GetCurrentUser(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Auth().RefreshSession(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Users().GetCurrentUser(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Auth().Logout(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Users().GetCurrentUser(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{
+		"/users/me sess-1",
+		"/auth/session/refresh sess-1",
+		"/users/me sess-2",
+		"/auth/logout sess-2",
+		"/users/me ",
+	}
+	if len(seen) != len(want) {
+		t.Fatalf("requests = %q", seen)
+	}
+	for i := range want {
+		if seen[i] != want[i] {
+			t.Errorf("request %d = %q, want %q", i, seen[i], want[i])
+		}
+	}
+}
+
+func TestSessionTokenSatisfiesValidate(t *testing.T) {
+	cfg := &Config{BaseURL: "https://example.test", SessionToken: "s"}
+	if err := cfg.Validate(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestPasswordManagement(t *testing.T) {
+	var calls int
+	var bodies []map[string]interface{}
+	c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		var body map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&body)
+		body["path"] = r.URL.Path
+		bodies = append(bodies, body)
+		if r.URL.Path == "/auth/reset-confirm" {
+			writeJSON(w, http.StatusServiceUnavailable, map[string]interface{}{"message": "down"})
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+	ctx := context.Background()
+	auth := c.Auth()
+
+	if err := auth.ChangePassword(ctx, "old-secret", "short"); err == nil {
+		t.Fatal("short password accepted")
+	}
+	if err := auth.ConfirmPasswordReset(ctx, "tok", "1234567"); err == nil {
+		t.Fatal("short reset password accepted")
+	}
+	if calls != 0 {
+		t.Fatalf("%d requests sent for invalid passwords", calls)
+	}
+
+	if err := auth.ChangePassword(ctx, "old-secret", "new-secret"); err != nil {
+		t.Fatal(err)
+	}
+	if err := auth.RequestPasswordReset(ctx, "dev@example.com"); err != nil {
+		t.Fatal(err)
+	}
+	if err := auth.ConfirmPasswordReset(ctx, "tok", "new-secret"); !errors.Is(err, ErrServer) {
+		t.Fatalf("ConfirmPasswordReset err = %v", err)
+	}
+
+	want := []map[string]interface{}{
+		{"path": "/auth/change-password", "old_password": "old-secret", "new_password": "new-secret"},
+		{"path": "/auth/reset-request", "email": "dev@example.com"},
+		{"path": "/auth/reset-confirm", "token": "tok", "new_password": "new-secret"},
+	}
+	if !reflect.DeepEqual(bodies, want) {
+		t.Fatalf("requests = %v\nwant %v (reset confirm must not be retried)", bodies, want)
+	}
+}
+
+func TestLoginMFA(t *testing.T) {
+	var mfaCalls int
+	c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]string
+		json.NewDecoder(r.Body).Decode(&body)
+		switch r.URL.Path {
+		case "/auth/login":
+			switch body["email"] {
+			case "mfa@example.com":
+				writeJSON(w, http.StatusOK, map[string]interface{}{"mfa_required": true, "challenge_token": "ch-1"})
+			case "mfa401@example.com":
+				writeJSON(w, http.StatusUnauthorized, map[string]interface{}{"error": map[string]interface{}{
+					"code": "mfa_required", "message": "second factor required",
+					"details": map[string]interface{}{"challenge_token": "ch-2"},
+				}})
+			default:
+				writeJSON(w, http.StatusOK, map[string]interface{}{"access_token": "tok", "refresh_token": "ref", "expires_in": 3600})
+			}
+		case "/auth/login/mfa":
+			mfaCalls++
+			if body["challenge_token"] != "ch-1" || body["code"] != "123456" {
+				writeJSON(w, http.StatusBadGateway, map[string]interface{}{"message": "bad"})
+				return
+			}
+			writeJSON(w, http.StatusOK, map[string]interface{}{"access_token": "mfa-tok", "expires_at": "2030-01-01T00:00:00Z"})
+		}
+	})
+	ctx := context.Background()
+	auth := c.Auth()
+
+	res, err := auth.LoginTyped(ctx, "plain@example.com", "pw")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Token != "tok" || res.RefreshToken != "ref" || res.MFARequired || time.Until(res.ExpiresAt) < 59*time.Minute {
+		t.Fatalf("plain login = %+v", res)
+	}
+
+	res, err = auth.LoginTyped(ctx, "mfa@example.com", "pw")
+	if err != nil || !res.MFARequired || res.ChallengeToken != "ch-1" || res.Token != "" {
+		t.Fatalf("mfa login = %+v, %v", res, err)
+	}
+	res, err = auth.LoginTyped(ctx, "mfa401@example.com", "pw")
+	if err != nil || !res.MFARequired || res.ChallengeToken != "ch-2" {
+		t.Fatalf("mfa login via 401 = %+v, %v", res, err)
+	}
+
+	res, err = auth.LoginMFA(ctx, "ch-1", "123456")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Token != "mfa-tok" || res.ExpiresAt.Year() != 2030 {
+		t.Fatalf("LoginMFA = %+v", res)
+	}
+	if _, err := auth.LoginMFA(ctx, "ch-1", "000000"); err == nil {
+		t.Fatal("expected an error for a rejected code")
+	}
+	if mfaCalls != 2 {
+		t.Fatalf("LoginMFA sent %d requests, want 2 (no retries)", mfaCalls)
+	}
+}
@@ -0,0 +1,113 @@
+package tavo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// BillingOperations groups the /billing endpoints.
+type BillingOperations struct {
+	client *Client
+}
+
+// GetSubscription fetches the current plan and subscription state.
+func (b *BillingOperations) GetSubscription(ctx context.Context) (map[string]interface{}, error) {
+	return b.client.makeRequest(ctx, http.MethodGet, "/billing/subscription", nil, nil)
+}
+
+// GetUsage fetches usage for the current billing period.
+func (b *BillingOperations) GetUsage(ctx context.Context) (map[string]interface{}, error) {
+	return b.client.makeRequest(ctx, http.MethodGet, "/billing/usage", nil, nil)
+}
+
+// UsageReport is usage over a time range, bucketed by period.
+type UsageReport struct {
+	Start       time.Time     `json:"start"`
+	End         time.Time     `json:"end"`
+	Granularity string        `json:"granularity"`
+	Periods     []UsagePeriod `json:"periods"`
+}
+
+// UsagePeriod is the usage in one bucket of a UsageReport.
+type UsagePeriod struct {
+	// Period is the start of the bucket.
+	Period     time.Time `json:"period"`
+	Scans      int       `json:"scans"`
+	AIAnalyses int       `json:"ai_analyses"`
+	Cost       float64   `json:"cost"`
+}
+
+// UsageGranularities are the bucket sizes accepted by GetUsageRange.
+var UsageGranularities = []string{"day", "week", "month"}
+
+// GetUsageRange fetches historical usage between from and to, bucketed by
+// granularity ("day", "week" or "month"). from must be before to.
+func (b *BillingOperations) GetUsageRange(ctx context.Context, from, to time.Time, granularity string) (*UsageReport, error) {
+	if !from.Before(to) {
+		return nil, errors.New("tavo: usage range start must be before its end")
+	}
+	valid := false
+	for _, g := range UsageGranularities {
+		valid = valid || g == granularity
+	}
+	if !valid {
+		return nil, fmt.Errorf("tavo: unknown usage granularity %q (want one of %v)", granularity, UsageGranularities)
+	}
+
+	params := map[string]interface{}{"start": from, "end": to, "granularity": granularity}
+	resp, err := b.client.makeRequest(ctx, http.MethodGet, "/billing/usage", nil, params)
+	if err != nil {
+		return nil, err
+	}
+	var report UsageReport
+	if err := decodeMap(resp, &report); err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
+
+// GetInvoices lists invoices.
+func (b *BillingOperations) GetInvoices(ctx context.Context, params map[string]interface{}) (map[string]interface{}, error) {
+	return b.client.makeRequest(ctx, http.MethodGet, "/billing/invoices", nil, params)
+}
+
+// Invoice is a billing invoice.
+type Invoice struct {
+	ID        string     `json:"id"`
+	Amount    float64    `json:"amount"`
+	Currency  string     `json:"currency"`
+	Status    string     `json:"status"`
+	IssuedAt  time.Time  `json:"issued_at"`
+	DueAt     time.Time  `json:"due_at"`
+	LineItems []LineItem `json:"line_items"`
+}
+
+// LineItem is one charge on an invoice.
+type LineItem struct {
+	Description string  `json:"description"`
+	Quantity    float64 `json:"quantity"`
+	UnitAmount  float64 `json:"unit_amount"`
+	Amount      float64 `json:"amount"`
+}
+
+// GetInvoice fetches an invoice by ID.
+func (b *BillingOperations) GetInvoice(ctx context.Context, invoiceID string) (*Invoice, error) {
+	resp, err := b.client.makeRequest(ctx, http.MethodGet, "/billing/invoices/"+invoiceID, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	var inv Invoice
+	if err := decodeMap(resp, &inv); err != nil {
+		return nil, err
+	}
+	return &inv, nil
+}
+
+// DownloadInvoice streams an invoice's PDF to w.
+func (b *BillingOperations) DownloadInvoice(ctx context.Context, invoiceID string, w io.Writer) error {
+	return b.client.download(ctx, "/billing/invoices/"+invoiceID+"/pdf", "application/pdf", w)
+}
@@ -0,0 +1,100 @@
+package tavo
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestGetUsageRange(t *testing.T) {
+	c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if r.URL.Path != "/billing/usage" || q.Get("start") != "2025-01-01T00:00:00Z" ||
+			q.Get("end") != "2025-03-01T00:00:00Z" || q.Get("granularity") != "month" {
+			t.Errorf("unexpected %s", r.URL)
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"granularity": "month",
+			"periods": []map[string]interface{}{
+				{"period": "2025-01-01T00:00:00Z", "scans": 120, "ai_analyses": 8, "cost": 42.5},
+				{"period": "2025-02-01T00:00:00Z", "scans": 90, "ai_analyses": 3, "cost": 30},
+			},
+		})
+	})
+
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	report, err := c.Billing().GetUsageRange(context.Background(), from, to, "month")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Periods) != 2 || report.Periods[0].Scans != 120 || report.Periods[0].Cost != 42.5 ||
+		report.Periods[1].Period.Month() != time.February || report.Periods[1].AIAnalyses != 3 {
+		t.Fatalf("report = %+v", report)
+	}
+}
+
+func TestGetUsageRangeValidation(t *testing.T) {
+	c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("request sent for invalid range")
+	})
+	now := time.Now()
+	ctx := context.Background()
+	if _, err := c.Billing().GetUsageRange(ctx, now, now.Add(-time.Hour), "day"); err == nil {
+		t.Error("accepted from after to")
+	}
+	if _, err := c.Billing().GetUsageRange(ctx, now, now, "day"); err == nil {
+		t.Error("accepted empty range")
+	}
+	if _, err := c.Billing().GetUsageRange(ctx, now.Add(-time.Hour), now, "hour"); err == nil {
+		t.Error("accepted unknown granularity")
+	}
+}
+
+func TestGetInvoice(t *testing.T) {
+	c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/billing/invoices/inv_1" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"id": "inv_1", "amount": 120.5, "currency": "USD", "status": "paid",
+			"issued_at": "2026-02-01T00:00:00Z", "due_at": "2026-03-01T00:00:00Z",
+			"line_items": []interface{}{
+				map[string]interface{}{"description": "Scans", "quantity": 100, "unit_amount": 1.2, "amount": 120},
+				map[string]interface{}{"description": "AI analyses", "quantity": 1, "unit_amount": 0.5, "amount": 0.5},
+			},
+		})
+	})
+
+	inv, err := c.Billing().GetInvoice(context.Background(), "inv_1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if inv.ID != "inv_1" || inv.Amount != 120.5 || inv.Currency != "USD" || inv.Status != "paid" {
+		t.Fatalf("invoice = %+v", inv)
+	}
+	if !inv.DueAt.Equal(time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)) || len(inv.LineItems) != 2 || inv.LineItems[0].Quantity != 100 {
+		t.Fatalf("invoice = %+v", inv)
+	}
+}
+
+func TestDownloadInvoice(t *testing.T) {
+	pdf := []byte("%PDF-1.7 fake invoice")
+	c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/billing/invoices/inv_1/pdf" || r.Header.Get("Accept") != "application/pdf" {
+			t.Errorf("unexpected %s with Accept %q", r.URL.Path, r.Header.Get("Accept"))
+		}
+		w.Header().Set("Content-Type", "application/pdf")
+		_, _ = w.Write(pdf)
+	})
+
+	var buf bytes.Buffer
+	if err := c.Billing().DownloadInvoice(context.Background(), "inv_1", &buf); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(buf.Bytes(), pdf) {
+		t.Fatalf("downloaded %q", buf.Bytes())
+	}
+}
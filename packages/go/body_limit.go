@@ -0,0 +1,51 @@
+package tavo
+
+import (
+	"errors"
+	"fmt"
+	"io"
+)
+
+// ErrBodyTooLarge is returned, wrapped, when a request body exceeds
+// Config.MaxBodyBytes. Nothing is sent for marshalled bodies; a streamed
+// body is cut off and the request aborted.
+var ErrBodyTooLarge = errors.New("tavo: request body too large")
+
+// maxBodyBytes returns the effective body limit, or -1 for none.
+func (c *Client) maxBodyBytes() int64 {
+	switch n := c.config.MaxBodyBytes; {
+	case n < 0:
+		return -1
+	case n == 0:
+		return DefaultMaxBodyBytes
+	default:
+		return n
+	}
+}
+
+func bodyTooLarge(limit int64) error {
+	return fmt.Errorf("%w: over the %d byte limit", ErrBodyTooLarge, limit)
+}
+
+// limitedBody reads a streamed request body, failing once more than limit
+// bytes have been read. err keeps the failure so execute can report it
+// rather than the transport's wrapping of it.
+type limitedBody struct {
+	r     io.Reader
+	limit int64
+	read  int64
+	err   error
+}
+
+func (l *limitedBody) Read(p []byte) (int, error) {
+	if l.err != nil {
+		return 0, l.err
+	}
+	n, err := l.r.Read(p)
+	l.read += int64(n)
+	if l.limit >= 0 && l.read > l.limit {
+		l.err = bodyTooLarge(l.limit)
+		return 0, l.err
+	}
+	return n, err
+}
@@ -0,0 +1,129 @@
+package tavo
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestMaxBodyBytesMarshalled(t *testing.T) {
+	var calls int32
+	c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		writeJSON(w, http.StatusOK, map[string]interface{}{"id": "s1"})
+	})
+	c.config.MaxBodyBytes = 64
+
+	_, err := c.Scans().CreateScan(context.Background(), map[string]interface{}{"code": strings.Repeat("x", 100)})
+	if !errors.Is(err, ErrBodyTooLarge) {
+		t.Fatalf("err = %v, want ErrBodyTooLarge", err)
+	}
+	if calls != 0 {
+		t.Errorf("calls = %d, want nothing sent", calls)
+	}
+
+	if _, err := c.Scans().CreateScan(context.Background(), map[string]interface{}{"name": "small"}); err != nil {
+		t.Fatal(err)
+	}
+	c.config.MaxBodyBytes = -1
+	if _, err := c.Scans().CreateScan(context.Background(), map[string]interface{}{"code": strings.Repeat("x", 100)}); err != nil {
+		t.Fatalf("limit disabled: %v", err)
+	}
+}
+
+func TestMarshalledBodyHasContentLength(t *testing.T) {
+	c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if r.ContentLength != int64(len(body)) || string(body) != `{"name":"s"}` {
+			t.Errorf("content length %d, body %q", r.ContentLength, body)
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{})
+	})
+
+	if _, err := c.Scans().CreateScan(context.Background(), map[string]interface{}{"name": "s"}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCreateScanFromReader(t *testing.T) {
+	var calls int32
+	c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&calls, 1)
+		body, _ := io.ReadAll(r.Body)
+		if r.URL.Path != "/scans" || r.Header.Get("Content-Type") != "application/json" || string(body) != `{"name":"streamed"}` {
+			t.Errorf("%s %q %q", r.URL.Path, r.Header.Get("Content-Type"), body)
+		}
+		if n == 1 {
+			writeJSON(w, http.StatusOK, map[string]interface{}{"id": "s1"})
+			return
+		}
+		writeJSON(w, http.StatusBadGateway, map[string]interface{}{"message": "down"})
+	})
+
+	resp, err := c.Scans().CreateScanFromReader(context.Background(), strings.NewReader(`{"name":"streamed"}`))
+	if err != nil || resp["id"] != "s1" {
+		t.Fatalf("resp = %v, err = %v", resp, err)
+	}
+
+	// A streamed body cannot be replayed, so a 502 is not retried.
+	if _, err := c.Scans().CreateScanFromReader(context.Background(), strings.NewReader(`{"name":"streamed"}`)); err == nil {
+		t.Fatal("expected error")
+	}
+	if calls != 2 {
+		t.Errorf("calls = %d, want 2", calls)
+	}
+}
+
+func TestAnalyzeCodeFromReaderTooLarge(t *testing.T) {
+	c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		io.Copy(io.Discard, r.Body)
+		writeJSON(w, http.StatusOK, map[string]interface{}{})
+	})
+	c.config.MaxBodyBytes = 1024
+
+	body := io.MultiReader(strings.NewReader(`{"code":"`), strings.NewReader(strings.Repeat("x", 4096)), strings.NewReader(`"}`))
+	_, err := c.AI().AnalyzeCodeFromReader(context.Background(), body)
+	if !errors.Is(err, ErrBodyTooLarge) {
+		t.Fatalf("err = %v, want ErrBodyTooLarge", err)
+	}
+}
+
+func TestReaderBodyIsStreamed(t *testing.T) {
+	received := make(chan struct{})
+	c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		first := make([]byte, 1)
+		if _, err := io.ReadFull(r.Body, first); err != nil {
+			t.Error(err)
+		}
+		close(received)
+		rest, _ := io.ReadAll(r.Body)
+		if got := string(first) + string(rest); got != `{"name":"streamed"}` {
+			t.Errorf("body = %q", got)
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{"id": "s1"})
+	})
+
+	// The second half is only written once the server has seen the first,
+	// which never happens if the body is buffered before sending.
+	pr, pw := io.Pipe()
+	go func() {
+		io.WriteString(pw, `{"name":`)
+		select {
+		case <-received:
+			io.WriteString(pw, `"streamed"}`)
+			pw.Close()
+		case <-time.After(5 * time.Second):
+			pw.CloseWithError(errors.New("request body was buffered"))
+		}
+	}()
+
+	resp, err := c.Scans().CreateScanFromReader(context.Background(), pr)
+	if err != nil || resp["id"] != "s1" {
+		t.Fatalf("resp = %v, err = %v", resp, err)
+	}
+}
@@ -0,0 +1,90 @@
+package tavo
+
+import (
+	"net/http"
+	"sync"
+	"time"
+)
+
+// CodeCircuitOpen is the TavoError code returned while the circuit breaker
+// is rejecting requests.
+const CodeCircuitOpen = "circuit_open"
+
+type breakerState int
+
+const (
+	breakerClosed breakerState = iota
+	breakerOpen
+	breakerHalfOpen
+)
+
+// circuitBreaker stops sending requests after threshold consecutive
+// failures. Once cooldown has elapsed it lets a single probe through: a
+// successful probe closes the circuit, a failed one reopens it.
+type circuitBreaker struct {
+	threshold int
+	cooldown  time.Duration
+	now       func() time.Time
+
+	mu       sync.Mutex
+	state    breakerState
+	failures int
+	openedAt time.Time
+}
+
+func newCircuitBreaker(threshold int, cooldown time.Duration) *circuitBreaker {
+	return &circuitBreaker{threshold: threshold, cooldown: cooldown, now: time.Now}
+}
+
+// allow reports whether a request may be sent now.
+func (b *circuitBreaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	switch b.state {
+	case breakerOpen:
+		if b.now().Sub(b.openedAt) < b.cooldown {
+			return false
+		}
+		b.state = breakerHalfOpen
+		return true
+	case breakerHalfOpen:
+		// A probe is already in flight.
+		return false
+	}
+	return true
+}
+
+// record reports the outcome of a request allowed by allow.
+func (b *circuitBreaker) record(success bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if success {
+		b.state = breakerClosed
+		b.failures = 0
+		return
+	}
+	b.failures++
+	if b.state == breakerHalfOpen || b.failures >= b.threshold {
+		b.state = breakerOpen
+		b.openedAt = b.now()
+	}
+}
+
+// release ends a request allowed by allow without judging the server, for
+// attempts the caller cancelled. A released probe lets the next request
+// probe again.
+func (b *circuitBreaker) release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.state == breakerHalfOpen {
+		b.state = breakerOpen
+	}
+}
+
+func circuitOpenError() *TavoError {
+	return &TavoError{
+		StatusCode: http.StatusServiceUnavailable,
+		Code:       CodeCircuitOpen,
+		Message:    "circuit breaker is open after repeated failures; request not sent",
+	}
+}
@@ -0,0 +1,192 @@
+package tavo
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCircuitBreakerOpensAndProbes(t *testing.T) {
+	var calls int32
+	var healthy atomic.Bool
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		if healthy.Load() {
+			writeJSON(w, http.StatusOK, map[string]interface{}{})
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+	c := newTestClientFor(t, srv, func(cfg *Config) {
+		cfg.WithMaxRetries(0).WithCircuitBreaker(3, time.Hour)
+	})
+	clock := time.Now()
+	c.breaker.now = func() time.Time { return clock }
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		if _, err := c.Jobs().GetJob(ctx, "j"); err == nil {
+			t.Fatal("expected server error")
+		}
+	}
+
+	// The circuit is open: many goroutines fail fast without reaching the server.
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := c.Jobs().GetJob(ctx, "j")
+			var tErr *TavoError
+			if !errors.As(err, &tErr) || tErr.Code != CodeCircuitOpen {
+				t.Errorf("err = %v, want circuit_open", err)
+			}
+		}()
+	}
+	wg.Wait()
+	if calls != 3 {
+		t.Fatalf("server calls = %d, want 3", calls)
+	}
+
+	// After the cooldown exactly one concurrent probe reaches the server.
+	clock = clock.Add(2 * time.Hour)
+	healthy.Store(true)
+	var open int32
+	start := make(chan struct{})
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			_, err := c.Jobs().GetJob(ctx, "j")
+			var tErr *TavoError
+			if errors.As(err, &tErr) && tErr.Code == CodeCircuitOpen {
+				atomic.AddInt32(&open, 1)
+			} else if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		}()
+	}
+	close(start)
+	wg.Wait()
+	if calls < 4 {
+		t.Fatalf("no probe was sent")
+	}
+
+	// The successful probe closed the circuit again.
+	if _, err := c.Jobs().GetJob(ctx, "j"); err != nil {
+		t.Fatalf("circuit did not close: %v", err)
+	}
+}
+
+func TestCircuitBreakerFailedProbeReopens(t *testing.T) {
+	b := newCircuitBreaker(1, time.Minute)
+	clock := time.Now()
+	b.now = func() time.Time { return clock }
+
+	if !b.allow() {
+		t.Fatal("closed breaker rejected a request")
+	}
+	b.record(false)
+	if b.allow() {
+		t.Fatal("open breaker allowed a request")
+	}
+
+	clock = clock.Add(time.Minute)
+	if !b.allow() {
+		t.Fatal("probe was not allowed after cooldown")
+	}
+	if b.allow() {
+		t.Fatal("second request allowed while probe in flight")
+	}
+	b.record(false)
+	if b.allow() {
+		t.Fatal("failed probe did not reopen the circuit")
+	}
+}
+
+func TestCircuitBreakerIgnoresCallerCancellation(t *testing.T) {
+	var healthy atomic.Bool
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("hang") != "" {
+			<-r.Context().Done()
+			return
+		}
+		if healthy.Load() {
+			writeJSON(w, http.StatusOK, map[string]interface{}{})
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+	c := newTestClientFor(t, srv, func(cfg *Config) {
+		cfg.WithMaxRetries(0).WithCircuitBreaker(2, time.Hour)
+	})
+	clock := time.Now()
+	c.breaker.now = func() time.Time { return clock }
+	hang := func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		if _, err := c.Jobs().ListJobs(ctx, map[string]interface{}{"hang": 1}); !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("err = %v, want deadline exceeded", err)
+		}
+	}
+	ctx := context.Background()
+
+	// Timeouts set by the caller do not count as failures.
+	hang()
+	hang()
+	hang()
+	if _, err := c.Jobs().GetJob(ctx, "j"); err == nil {
+		t.Fatal("expected server error")
+	}
+	if _, err := c.Jobs().GetJob(ctx, "j"); err == nil {
+		t.Fatal("expected server error")
+	}
+
+	// A cancelled probe neither reopens the circuit nor blocks the next probe.
+	clock = clock.Add(2 * time.Hour)
+	hang()
+	healthy.Store(true)
+	if _, err := c.Jobs().GetJob(ctx, "j"); err != nil {
+		t.Fatalf("probe after a cancelled probe: %v", err)
+	}
+}
+
+func TestCircuitBreakerGuardsDownloads(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+	c := newTestClientFor(t, srv, func(cfg *Config) {
+		cfg.WithMaxRetries(0).WithCircuitBreaker(1, time.Hour)
+	})
+	ctx := context.Background()
+
+	// A failed download opens the circuit...
+	if err := c.Reports().DownloadReportTo(ctx, "r1", io.Discard); err == nil {
+		t.Fatal("expected server error")
+	}
+	// ...and an open circuit stops downloads and streams too.
+	var tErr *TavoError
+	err := c.Reports().DownloadReportTo(ctx, "r1", io.Discard)
+	if !errors.As(err, &tErr) || tErr.Code != CodeCircuitOpen {
+		t.Fatalf("download err = %v, want circuit_open", err)
+	}
+	err = c.Scans().StreamScanResults(ctx, "s1", func(map[string]interface{}) error { return nil })
+	if !errors.As(err, &tErr) || tErr.Code != CodeCircuitOpen {
+		t.Fatalf("stream err = %v, want circuit_open", err)
+	}
+	if calls != 1 {
+		t.Errorf("server calls = %d, want 1", calls)
+	}
+}
@@ -0,0 +1,593 @@
+package tavo
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// Client talks to the Tavo AI API. It is safe for concurrent use.
+type Client struct {
+	config  *Config
+	http    *resty.Client
+	breaker *circuitBreaker
+	session *sessionState
+	debug   *debugLog
+	closer  *closeState
+
+	lastRequestID *atomic.Value
+
+	auth          *AuthOperations
+	users         *UserOperations
+	organizations *OrganizationOperations
+	scans         *ScanOperations
+	scanRules     *ScanRuleOperations
+	jobs          *JobOperations
+	reports       *ReportOperations
+	webhooks      *WebhookOperations
+	ai            *AIAnalysisOperations
+	billing       *BillingOperations
+}
+
+// NewClient builds a Client from config. A nil config is equivalent to
+// NewConfig().
+func NewClient(config *Config) (*Client, error) {
+	if config == nil {
+		config = NewConfig()
+	}
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	return newClient(config, nil), nil
+}
+
+// newClient builds a Client from a validated config. When shared is
+// non-nil it is used as the transport as is, so the caller is responsible
+// for any wrapping (such as rate limiting) it needs.
+func newClient(config *Config, shared http.RoundTripper) *Client {
+	var httpClient *resty.Client
+	if shared != nil {
+		httpClient = resty.NewWithClient(&http.Client{Transport: shared})
+	} else {
+		httpClient = resty.New()
+	}
+	httpClient.
+		SetBaseURL(apiBaseURL(config)).
+		SetTimeout(config.Timeout).
+		SetHeader("Accept", "application/json").
+		SetHeader("User-Agent", userAgent(config))
+
+	if config.TokenSource != nil || (config.JWTToken == "" && config.SessionToken != "") {
+		// applyCredentials sets the bearer or session token per request.
+	} else if config.JWTToken != "" {
+		httpClient.SetAuthToken(config.JWTToken)
+	} else if config.APIKey != "" {
+		httpClient.SetHeader("X-API-Key", config.APIKey)
+	}
+	if config.DualAuth && config.APIKey != "" {
+		// The user credential set above is kept; see Config.WithDualAuth.
+		httpClient.SetHeader("X-API-Key", config.APIKey)
+	}
+	if config.OrganizationID != "" {
+		httpClient.SetHeader("X-Organization-ID", config.OrganizationID)
+	}
+	httpClient.SetHeaders(config.DefaultHeaders)
+
+	closer := &closeState{}
+	if t, ok := httpClient.GetClient().Transport.(*http.Transport); ok && shared == nil {
+		tunePool(t, config)
+		closer.idle = t
+	}
+	switch {
+	case config.ReplayDir != "":
+		httpClient.SetTransport(&ReplayTransport{Dir: config.ReplayDir})
+	case config.RecordDir != "":
+		httpClient.SetTransport(&RecordingTransport{Dir: config.RecordDir, Next: httpClient.GetClient().Transport})
+	}
+	var debug *debugLog
+	if config.Debug {
+		debug = newDebugLog(config.DebugLogSize)
+		httpClient.SetTransport(&debugTransport{next: httpClient.GetClient().Transport, log: debug})
+		if config.Logger != nil {
+			enableRestyDebug(httpClient, config.Logger)
+		}
+	}
+	if config.RateLimit > 0 && shared == nil {
+		httpClient.SetTransport(newRateLimitTransport(httpClient.GetClient().Transport, config))
+	}
+	if len(config.Middlewares) > 0 {
+		httpClient.SetTransport(chainMiddlewares(httpClient.GetClient().Transport, config.Middlewares))
+	}
+	httpClient.SetTransport(&traceTransport{next: httpClient.GetClient().Transport, host: apiHost(config)})
+	lastRequestID := &atomic.Value{}
+	httpClient.SetTransport(&requestIDTransport{next: httpClient.GetClient().Transport, host: apiHost(config), last: lastRequestID})
+	httpClient.SetTransport(&closedGuard{next: httpClient.GetClient().Transport, state: closer})
+
+	c := &Client{config: config, http: httpClient, debug: debug, closer: closer, lastRequestID: lastRequestID}
+	if config.TokenSource == nil && config.JWTToken == "" && config.SessionToken != "" {
+		c.session = &sessionState{token: config.SessionToken}
+	}
+	if config.CircuitBreakerThreshold > 0 {
+		c.breaker = newCircuitBreaker(config.CircuitBreakerThreshold, config.CircuitBreakerCooldown)
+	}
+	c.auth = &AuthOperations{client: c}
+	c.users = &UserOperations{client: c}
+	c.organizations = &OrganizationOperations{client: c}
+	c.scans = &ScanOperations{client: c}
+	c.scanRules = &ScanRuleOperations{client: c}
+	c.jobs = &JobOperations{client: c}
+	c.reports = &ReportOperations{client: c}
+	c.webhooks = &WebhookOperations{client: c}
+	c.ai = &AIAnalysisOperations{client: c}
+	c.billing = &BillingOperations{client: c}
+	return c
+}
+
+// tunePool applies the configured connection pool limits to t.
+func tunePool(t *http.Transport, config *Config) {
+	if config.MaxIdleConns > 0 {
+		t.MaxIdleConns = config.MaxIdleConns
+	}
+	if config.MaxIdleConnsPerHost > 0 {
+		t.MaxIdleConnsPerHost = config.MaxIdleConnsPerHost
+	}
+	if config.IdleConnTimeout > 0 {
+		t.IdleConnTimeout = config.IdleConnTimeout
+	}
+}
+
+// apiBaseURL is the URL every operation path is relative to: BaseURL
+// followed by /api/{APIVersion}. This is the only place the version prefix
+// is applied, so operations use paths such as "/scans". A path in BaseURL,
+// such as a reverse-proxy prefix, is kept, with or without a trailing
+// slash.
+func apiBaseURL(config *Config) string {
+	u, err := url.Parse(config.BaseURL)
+	if err != nil {
+		// Validate rejects unparseable URLs; fall back for unvalidated
+		// configs built by hand.
+		return strings.TrimRight(config.BaseURL, "/")
+	}
+	if config.APIVersion != "" {
+		u = u.JoinPath("api", config.APIVersion)
+	}
+	u.Path = strings.TrimRight(u.Path, "/")
+	u.RawPath = ""
+	return u.String()
+}
+
+// Config returns the configuration the client was built with.
+func (c *Client) Config() *Config { return c.config }
+
+// Auth returns authentication operations.
+func (c *Client) Auth() *AuthOperations { return c.auth }
+
+// Users returns user and API key operations.
+func (c *Client) Users() *UserOperations { return c.users }
+
+// Organizations returns organization operations.
+func (c *Client) Organizations() *OrganizationOperations { return c.organizations }
+
+// Scans returns scan operations.
+func (c *Client) Scans() *ScanOperations { return c.scans }
+
+// ScanRules returns scan rule operations.
+func (c *Client) ScanRules() *ScanRuleOperations { return c.scanRules }
+
+// Jobs returns job operations.
+func (c *Client) Jobs() *JobOperations { return c.jobs }
+
+// Reports returns report operations.
+func (c *Client) Reports() *ReportOperations { return c.reports }
+
+// Webhooks returns webhook operations.
+func (c *Client) Webhooks() *WebhookOperations { return c.webhooks }
+
+// AI returns AI analysis operations.
+func (c *Client) AI() *AIAnalysisOperations { return c.ai }
+
+// Billing returns billing operations.
+func (c *Client) Billing() *BillingOperations { return c.billing }
+
+// apiRequest describes one logical API call. execute may send it several
+// times.
+type apiRequest struct {
+	method  string
+	path    string
+	body    interface{}
+	params  map[string]interface{}
+	headers map[string]string
+	noRetry bool
+}
+
+// Do sends a request to any API path, relative to the versioned base URL,
+// and decodes the JSON object response. It behaves like the typed
+// operations, including retries; opts apply after any options attached to
+// ctx. Use it for endpoints the SDK does not wrap yet. A body that is an
+// io.Reader is streamed as JSON as is, and the request is not retried.
+//
+// On success the returned map is never nil. A 204 or empty body yields an
+// empty map, and a top-level JSON array is returned under "items".
+func (c *Client) Do(ctx context.Context, method, path string, body interface{}, params map[string]interface{}, opts ...RequestOption) (map[string]interface{}, error) {
+	if len(opts) > 0 {
+		ctx = WithRequestOptions(ctx, opts...)
+	}
+	return c.makeRequest(ctx, method, path, body, params)
+}
+
+// makeRequest sends a JSON request and decodes the JSON object response.
+// Network errors, 429 and 5xx responses are retried with exponential backoff.
+// On success the map is never nil (see decodeObject), so callers may index
+// it without checking.
+func (c *Client) makeRequest(ctx context.Context, method, path string, body interface{}, params map[string]interface{}) (map[string]interface{}, error) {
+	resp, err := c.execute(ctx, &apiRequest{method: method, path: path, body: body, params: params})
+	if err != nil {
+		return nil, err
+	}
+	return checkResponse(resp)
+}
+
+// execute sends req, retrying network errors and retryable statuses. It
+// returns the first non-retryable response whatever its status, or the last
+// response once retries are exhausted.
+func (c *Client) execute(ctx context.Context, req *apiRequest) (*resty.Response, error) {
+	var (
+		lastResp *resty.Response
+		lastErr  error
+	)
+	for _, opt := range requestOptionsFromContext(ctx) {
+		opt(req)
+	}
+
+	req.params = c.withDefaultParams(req.method, req.path, req.params)
+
+	// A streamed body can only be read once, so it is never retried.
+	stream, streamed := req.body.(io.Reader)
+	maxRetries := c.config.MaxRetries
+	if req.noRetry || streamed {
+		maxRetries = 0
+	}
+	var limited *limitedBody
+	for attempt := 0; attempt <= maxRetries; attempt++ {
+		if c.closer.closed.Load() {
+			return nil, ErrClientClosed
+		}
+		if attempt > 0 {
+			wait := c.config.RetryWait << (attempt - 1)
+			c.logf("tavo: retrying %s %s in %s (attempt %d): %s", req.method, req.path, wait, attempt, retryReason(lastResp, lastErr))
+			c.notifyRetry(attempt, req.method, req.path, wait, lastResp, lastErr)
+			c.slogRetry(ctx, req, attempt, wait, lastResp, lastErr)
+			if err := sleepContext(ctx, wait); err != nil {
+				return nil, err
+			}
+		}
+
+		r := c.http.R().
+			SetContext(ctx).
+			SetQueryParamsFromValues(encodeParams(req.params))
+		if c.config.Compression {
+			// Setting the header disables net/http's transparent decoding;
+			// resty decodes parsed gzip bodies itself. Raw responses are
+			// decoded by decodeContentEncoding instead.
+			r.SetHeader("Accept-Encoding", "gzip")
+		}
+		r.SetHeaders(req.headers)
+		switch {
+		case streamed:
+			limited = &limitedBody{r: stream, limit: c.maxBodyBytes()}
+			r.SetHeader("Content-Type", "application/json")
+		case req.body != nil:
+			body, encoding, err := c.encodeBody(req.body)
+			if err != nil {
+				return nil, err
+			}
+			// A bytes.Reader is sent as is; resty would copy a []byte
+			// body twice more.
+			r.SetHeader("Content-Type", "application/json").SetBody(bytes.NewReader(body))
+			if encoding != "" {
+				r.SetHeader("Content-Encoding", encoding)
+			}
+		}
+
+		if err := c.applyCredentials(r); err != nil {
+			return nil, err
+		}
+
+		resp, err := c.sendAttempt(ctx, req, attempt, func() (*resty.Response, error) {
+			if streamed {
+				return c.doStreamed(ctx, req.method, req.path, r, limited, false)
+			}
+			return r.Execute(req.method, req.path)
+		})
+		if te, ok := err.(*TavoError); ok {
+			return nil, te // the circuit is open
+		}
+		tooLarge := limited != nil && limited.err != nil
+		if tooLarge {
+			return nil, limited.err
+		}
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			lastResp, lastErr = nil, fmt.Errorf("tavo: %s %s: %w", req.method, req.path, err)
+			continue
+		}
+		if isRetryableStatus(resp.StatusCode()) {
+			lastResp, lastErr = resp, nil
+			continue
+		}
+		return resp, nil
+	}
+	if lastResp != nil {
+		return lastResp, nil
+	}
+	return nil, lastErr
+}
+
+// sendAttempt makes one attempt of req by calling send, guarded by the
+// circuit breaker and reported to Metrics and the slog logger. Every path
+// that talks to the API goes through it, including downloads, streams and
+// uploads. While the circuit is open send is not called and the
+// *TavoError from circuitOpenError is returned; send itself must not
+// return a *TavoError. Attempts ended by the caller's context or by
+// ErrBodyTooLarge say nothing about the server and are not counted by the
+// breaker.
+func (c *Client) sendAttempt(ctx context.Context, req *apiRequest, attempt int, send func() (*resty.Response, error)) (*resty.Response, error) {
+	if c.breaker != nil && !c.breaker.allow() {
+		return nil, circuitOpenError()
+	}
+	start := time.Now()
+	resp, err := send()
+	elapsed := time.Since(start)
+	c.observe(req, resp, err, elapsed)
+	c.slogAttempt(ctx, req, attempt, resp, err, elapsed)
+	if c.breaker != nil {
+		if err != nil && (ctx.Err() != nil || errors.Is(err, ErrBodyTooLarge)) {
+			c.breaker.release()
+		} else {
+			c.breaker.record(err == nil && !isRetryableStatus(resp.StatusCode()))
+		}
+	}
+	return resp, err
+}
+
+// withDefaultParams merges the configured default params of a GET request
+// under params, which win. Other methods are returned unchanged.
+func (c *Client) withDefaultParams(method, path string, params map[string]interface{}) map[string]interface{} {
+	scoped := c.config.EndpointParams[path]
+	if method != http.MethodGet || (len(c.config.DefaultParams) == 0 && len(scoped) == 0) {
+		return params
+	}
+	merged := copyParams(c.config.DefaultParams)
+	for k, v := range scoped {
+		merged[k] = v
+	}
+	for k, v := range params {
+		merged[k] = v
+	}
+	return merged
+}
+
+// observe reports one attempt to the configured Metrics. Network errors
+// are reported with status 0.
+func (c *Client) observe(req *apiRequest, resp *resty.Response, err error, d time.Duration) {
+	if c.config.Metrics == nil {
+		return
+	}
+	status := 0
+	if err == nil {
+		status = resp.StatusCode()
+	}
+	c.config.Metrics.ObserveRequest(req.method, req.path, status, d)
+}
+
+// applyCredentials sets the per-request credentials on r: a bearer token
+// from the configured TokenSource, or the current session token. Static
+// credentials are set once on the resty client instead.
+func (c *Client) applyCredentials(r *resty.Request) error {
+	if ts := c.config.TokenSource; ts != nil {
+		token, err := ts.Token()
+		if err != nil {
+			return fmt.Errorf("tavo: fetching token: %w", err)
+		}
+		r.SetAuthToken(token)
+		return nil
+	}
+	if c.session != nil {
+		if token := c.session.get(); token != "" {
+			r.SetHeader("X-Session-Token", token)
+		}
+	}
+	return nil
+}
+
+func retryReason(resp *resty.Response, err error) string {
+	if resp != nil {
+		return fmt.Sprintf("status %d", resp.StatusCode())
+	}
+	return err.Error()
+}
+
+func (c *Client) logf(format string, args ...interface{}) {
+	if c.config.Logger != nil {
+		c.config.Logger(format, args...)
+	}
+}
+
+func isRetryableStatus(status int) bool {
+	return status == http.StatusTooManyRequests || status >= 500
+}
+
+// decodeObject decodes a JSON object body. It never returns a nil map
+// without an error: an empty, whitespace-only or null body decodes to an
+// empty map, and a top-level array is wrapped as {"items": [...]} so list
+// helpers such as pageItems can read it.
+func decodeObject(body []byte) (map[string]interface{}, error) {
+	body = bytes.TrimSpace(body)
+	if len(body) == 0 {
+		return map[string]interface{}{}, nil
+	}
+	if body[0] == '[' {
+		var items []interface{}
+		if err := json.Unmarshal(body, &items); err != nil {
+			return nil, fmt.Errorf("tavo: decoding response: %w", err)
+		}
+		if items == nil {
+			items = []interface{}{}
+		}
+		return map[string]interface{}{"items": items}, nil
+	}
+	var result map[string]interface{}
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("tavo: decoding response: %w", err)
+	}
+	if result == nil {
+		result = map[string]interface{}{}
+	}
+	return result, nil
+}
+
+// encodeParams converts query parameters to url.Values. Slices become
+// repeated keys and times are formatted as RFC 3339 in UTC.
+func encodeParams(params map[string]interface{}) url.Values {
+	values := url.Values{}
+	for key, value := range params {
+		switch v := value.(type) {
+		case nil:
+		case []string:
+			for _, s := range v {
+				values.Add(key, s)
+			}
+		case []interface{}:
+			for _, s := range v {
+				values.Add(key, fmt.Sprint(s))
+			}
+		case time.Time:
+			values.Set(key, v.UTC().Format(time.RFC3339))
+		default:
+			values.Set(key, fmt.Sprint(v))
+		}
+	}
+	return values
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// checkResponse converts a single non-retried response into a result map or
+// a *TavoError, which unwraps to the sentinel matching its status.
+func checkResponse(resp *resty.Response) (map[string]interface{}, error) {
+	if status := resp.StatusCode(); status < 200 || status > 299 {
+		return nil, responseError(status, resp.Header(), resp.Body())
+	}
+	return decodeObject(resp.Body())
+}
+
+// download streams the body of a GET to w without buffering it. When the
+// response carries X-Content-SHA256, the streamed bytes are checked
+// against it after the copy.
+func (c *Client) download(ctx context.Context, path, accept string, w io.Writer) error {
+	body, header, err := c.openStream(ctx, path, nil, accept)
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+
+	want := header.Get("X-Content-SHA256")
+	h := sha256.New()
+	if want != "" {
+		w = io.MultiWriter(w, h)
+	}
+	if _, err := io.Copy(w, body); err != nil {
+		return fmt.Errorf("tavo: reading %s: %w", path, err)
+	}
+	if want != "" {
+		if err := verifySHA256(h.Sum(nil), want); err != nil {
+			return fmt.Errorf("tavo: downloading %s: %w", path, err)
+		}
+	}
+	return nil
+}
+
+// ErrChecksumMismatch is returned when downloaded bytes do not match the
+// checksum the server sent. The bytes have already been written to the
+// caller's writer and should be discarded.
+var ErrChecksumMismatch = errors.New("checksum mismatch")
+
+// verifySHA256 compares sum with a hex or base64 encoded digest.
+func verifySHA256(sum []byte, want string) error {
+	expected, err := hex.DecodeString(want)
+	if err != nil {
+		if expected, err = base64.StdEncoding.DecodeString(want); err != nil {
+			return fmt.Errorf("malformed X-Content-SHA256 %q", want)
+		}
+	}
+	if !bytes.Equal(sum, expected) {
+		return fmt.Errorf("%w: got sha256 %x, want %s", ErrChecksumMismatch, sum, want)
+	}
+	return nil
+}
+
+// openStream sends a GET and returns the unread body and headers of a 2xx
+// response. The caller must close the body.
+func (c *Client) openStream(ctx context.Context, path string, params map[string]interface{}, accept string) (io.ReadCloser, http.Header, error) {
+	resp, err := c.openRaw(ctx, path, params, map[string]string{"Accept": accept})
+	if err != nil {
+		return nil, nil, err
+	}
+	return resp.RawBody(), resp.Header(), nil
+}
+
+// openRaw sends a GET with headers and returns a 2xx response with its
+// body unread; the caller must close resp.RawBody(). Other statuses are
+// returned as a *TavoError.
+func (c *Client) openRaw(ctx context.Context, path string, params map[string]interface{}, headers map[string]string) (*resty.Response, error) {
+	r := c.http.R().
+		SetContext(ctx).
+		SetHeaders(headers).
+		SetQueryParamsFromValues(encodeParams(c.withDefaultParams(http.MethodGet, path, params))).
+		SetDoNotParseResponse(true)
+	if err := c.applyCredentials(r); err != nil {
+		return nil, err
+	}
+	req := &apiRequest{method: http.MethodGet, path: path, params: params, headers: headers}
+	resp, err := c.sendAttempt(ctx, req, 0, func() (*resty.Response, error) { return r.Get(path) })
+	if te, ok := err.(*TavoError); ok {
+		return nil, te
+	}
+	if err != nil {
+		return nil, fmt.Errorf("tavo: GET %s: %w", path, err)
+	}
+	if err := decodeContentEncoding(resp.RawResponse); err != nil {
+		return nil, fmt.Errorf("tavo: GET %s: %w", path, err)
+	}
+	if status := resp.StatusCode(); status < 200 || status > 299 {
+		body := resp.RawBody()
+		defer body.Close()
+		data, _ := io.ReadAll(body)
+		return nil, responseError(status, resp.Header(), data)
+	}
+	return resp, nil
+}
@@ -0,0 +1,402 @@
+package tavo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newTestClient starts an httptest server running handler and returns a
+// client pointed at it with fast retries.
+func newTestClient(t *testing.T, handler http.HandlerFunc) (*Client, *httptest.Server) {
+	t.Helper()
+	srv := httptest.NewServer(apiHandler(t, handler))
+	t.Cleanup(srv.Close)
+	return newTestClientFor(t, srv, nil), srv
+}
+
+// apiHandler checks that requests carry the /api/v1 prefix and strips it,
+// so handlers match on operation paths such as "/scans".
+func apiHandler(t *testing.T, handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.URL.Path, "/api/v1/") {
+			t.Errorf("path %s lacks the /api/v1 prefix", r.URL.Path)
+		}
+		r.URL.Path = strings.TrimPrefix(r.URL.Path, "/api/v1")
+		handler(w, r)
+	}
+}
+
+func newTestClientFor(t *testing.T, srv *httptest.Server, configure func(*Config)) *Client {
+	t.Helper()
+	cfg := NewConfig().
+		WithAPIKey("test-key").
+		WithBaseURL(srv.URL).
+		WithRetryWait(time.Millisecond)
+	if configure != nil {
+		configure(cfg)
+	}
+	c, err := NewClient(cfg)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	return c
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func TestMakeRequestSendsAuthAndDecodes(t *testing.T) {
+	c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("X-API-Key"); got != "test-key" {
+			t.Errorf("X-API-Key = %q", got)
+		}
+		if got := r.URL.Query().Get("status"); got != "running" {
+			t.Errorf("status param = %q", got)
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{"id": "scan-1"})
+	})
+
+	resp, err := c.Scans().ListScans(context.Background(), map[string]interface{}{"status": "running"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp["id"] != "scan-1" {
+		t.Fatalf("resp = %v", resp)
+	}
+}
+
+func TestMakeRequestJWTTakesPrecedence(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "Bearer jwt" {
+			t.Errorf("Authorization = %q", got)
+		}
+		if got := r.Header.Get("X-API-Key"); got != "" {
+			t.Errorf("X-API-Key = %q, want empty", got)
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{})
+	}))
+	defer srv.Close()
+	c := newTestClientFor(t, srv, func(cfg *Config) { cfg.WithJWTToken("jwt") })
+
+	if _, err := c.Scans().GetScan(context.Background(), "1"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestMakeRequestReturnsTavoError(t *testing.T) {
+	c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusBadRequest, map[string]interface{}{
+			"error": map[string]interface{}{"code": "invalid_target", "message": "target is required"},
+		})
+	})
+
+	_, err := c.Scans().CreateScan(context.Background(), map[string]interface{}{})
+	var tErr *TavoError
+	if !errors.As(err, &tErr) {
+		t.Fatalf("err = %v, want *TavoError", err)
+	}
+	if tErr.StatusCode != 400 || tErr.Code != "invalid_target" || tErr.Message != "target is required" {
+		t.Fatalf("got %+v", tErr)
+	}
+}
+
+func TestMakeRequestRetriesServerErrors(t *testing.T) {
+	var calls int32
+	c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{"ok": true})
+	})
+
+	if _, err := c.Jobs().GetJob(context.Background(), "j1"); err != nil {
+		t.Fatal(err)
+	}
+	if calls != 3 {
+		t.Fatalf("calls = %d, want 3", calls)
+	}
+}
+
+func TestMakeRequestDoesNotRetryClientErrors(t *testing.T) {
+	var calls int32
+	c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	if _, err := c.Jobs().GetJob(context.Background(), "j1"); err == nil {
+		t.Fatal("expected error")
+	}
+	if calls != 1 {
+		t.Fatalf("calls = %d, want 1", calls)
+	}
+}
+
+func TestMakeRequestNeverReturnsNilMap(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		body   string
+		items  int // -1 when no "items" key is expected
+	}{
+		{"no content", http.StatusNoContent, "", -1},
+		{"empty body", http.StatusOK, "", -1},
+		{"whitespace", http.StatusOK, " \n\t ", -1},
+		{"null", http.StatusOK, "null", -1},
+		{"empty array", http.StatusOK, "[]", 0},
+		{"array", http.StatusOK, `[{"id":"s1"},{"id":"s2"}]`, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			})
+
+			resp, err := c.Do(context.Background(), http.MethodGet, "/scans", nil, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if resp == nil {
+				t.Fatal("nil map")
+			}
+			items, ok := resp["items"].([]interface{})
+			if tt.items < 0 {
+				if len(resp) != 0 {
+					t.Errorf("resp = %v, want empty", resp)
+				}
+				return
+			}
+			if !ok || len(items) != tt.items {
+				t.Errorf("items = %#v, want %d", resp["items"], tt.items)
+			}
+		})
+	}
+}
+
+func TestMakeRequestMalformedBody(t *testing.T) {
+	c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("[{"))
+	})
+
+	resp, err := c.Do(context.Background(), http.MethodGet, "/scans", nil, nil)
+	if err == nil || resp != nil {
+		t.Fatalf("resp = %v, err = %v", resp, err)
+	}
+}
+
+func TestIterateScansFollowsPages(t *testing.T) {
+	c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var items []map[string]interface{}
+		switch r.URL.Query().Get("offset") {
+		case "0":
+			items = []map[string]interface{}{{"id": "a"}, {"id": "b"}}
+		case "2":
+			items = []map[string]interface{}{{"id": "c"}}
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{"items": items, "total": 3})
+	})
+
+	it := c.Scans().IterateScans(context.Background(), map[string]interface{}{"limit": 2})
+	var ids []string
+	for it.Next() {
+		ids = append(ids, it.Item()["id"].(string))
+	}
+	if err := it.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if len(ids) != 3 || ids[2] != "c" {
+		t.Fatalf("ids = %v", ids)
+	}
+}
+
+func TestAPIVersionPrefix(t *testing.T) {
+	for _, tc := range []struct{ version, want string }{
+		{"v1", "/api/v1/scans/s1"},
+		{"v2", "/api/v2/scans/s1"},
+		{"", "/scans/s1"},
+	} {
+		var got string
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			got = r.URL.Path
+			writeJSON(w, http.StatusOK, map[string]interface{}{})
+		}))
+		c := newTestClientFor(t, srv, func(cfg *Config) { cfg.WithAPIVersion(tc.version) })
+		if _, err := c.Scans().GetScan(context.Background(), "s1"); err != nil {
+			t.Fatal(err)
+		}
+		srv.Close()
+		if got != tc.want {
+			t.Errorf("version %q: path = %s, want %s", tc.version, got, tc.want)
+		}
+	}
+}
+
+func TestWithConnectionPool(t *testing.T) {
+	transport := func(cfg *Config) *http.Transport {
+		c, err := NewClient(cfg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return c.closer.idle
+	}
+
+	tr := transport(NewConfig().WithAPIKey("k"))
+	if tr.MaxIdleConns != DefaultMaxIdleConns || tr.MaxIdleConnsPerHost != DefaultMaxIdleConns || tr.IdleConnTimeout != DefaultIdleConnTimeout {
+		t.Errorf("defaults: %d/%d/%s", tr.MaxIdleConns, tr.MaxIdleConnsPerHost, tr.IdleConnTimeout)
+	}
+
+	tr = transport(NewConfig().WithAPIKey("k").WithConnectionPool(500, 250, 2*time.Minute))
+	if tr.MaxIdleConns != 500 || tr.MaxIdleConnsPerHost != 250 || tr.IdleConnTimeout != 2*time.Minute {
+		t.Errorf("tuned: %d/%d/%s", tr.MaxIdleConns, tr.MaxIdleConnsPerHost, tr.IdleConnTimeout)
+	}
+}
+
+func TestBaseURLPathPrefix(t *testing.T) {
+	for _, tc := range []struct{ prefix, want string }{
+		{"", "/api/v1/scans/s1"},
+		{"/", "/api/v1/scans/s1"},
+		{"/tavo", "/tavo/api/v1/scans/s1"},
+		{"/tavo/", "/tavo/api/v1/scans/s1"},
+		{"/gateway/tavo//", "/gateway/tavo/api/v1/scans/s1"},
+	} {
+		var got string
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			got = r.URL.Path
+			writeJSON(w, http.StatusOK, map[string]interface{}{})
+		}))
+		c := newTestClientFor(t, srv, func(cfg *Config) { cfg.WithBaseURL(srv.URL + tc.prefix) })
+		if _, err := c.Scans().GetScan(context.Background(), "s1"); err != nil {
+			t.Fatal(err)
+		}
+		srv.Close()
+		if got != tc.want {
+			t.Errorf("base %q: path = %s, want %s", tc.prefix, got, tc.want)
+		}
+	}
+}
+
+func TestIterateScansCursor(t *testing.T) {
+	var cursors []string
+	c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("status") != "completed" || q.Get("offset") != "" {
+			t.Errorf("query = %s", r.URL.RawQuery)
+		}
+		cursors = append(cursors, q.Get("cursor"))
+		switch q.Get("cursor") {
+		case "":
+			writeJSON(w, http.StatusOK, map[string]interface{}{"items": []map[string]interface{}{{"id": "a"}, {"id": "b"}}, "next_cursor": "opaque-1"})
+		case "opaque-1":
+			writeJSON(w, http.StatusOK, map[string]interface{}{"items": []map[string]interface{}{{"id": "c"}}, "next_cursor": ""})
+		default:
+			t.Errorf("unexpected cursor %q", q.Get("cursor"))
+		}
+	})
+
+	it := c.Scans().IterateScansCursor(context.Background(), map[string]interface{}{"status": "completed"})
+	var ids []string
+	for it.Next() {
+		ids = append(ids, it.Item()["id"].(string))
+	}
+	if err := it.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(ids, ",") != "a,b,c" || strings.Join(cursors, ",") != ",opaque-1" {
+		t.Fatalf("ids = %v, cursors = %q", ids, cursors)
+	}
+}
+
+func TestIterateScansCursorRepeatedCursor(t *testing.T) {
+	calls := 0
+	c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		writeJSON(w, http.StatusOK, map[string]interface{}{"items": []map[string]interface{}{{"id": "a"}}, "next_cursor": "same"})
+	})
+
+	it := c.Scans().IterateScansCursor(context.Background(), nil)
+	for it.Next() {
+	}
+	if it.Err() == nil || calls != 2 {
+		t.Fatalf("err = %v after %d calls", it.Err(), calls)
+	}
+}
+
+func TestDualAuth(t *testing.T) {
+	type sent struct{ auth, apiKey, session string }
+	for _, tc := range []struct {
+		name      string
+		configure func(*Config)
+		want      sent
+	}{
+		{"jwt only by precedence", func(c *Config) { c.WithJWTToken("jwt") }, sent{auth: "Bearer jwt"}},
+		{"jwt and key", func(c *Config) { c.WithJWTToken("jwt").WithDualAuth(true) }, sent{auth: "Bearer jwt", apiKey: "test-key"}},
+		{"session and key", func(c *Config) { c.WithSessionToken("sess").WithDualAuth(true) }, sent{apiKey: "test-key", session: "sess"}},
+		{"token source and key", func(c *Config) {
+			c.WithTokenSource(StaticTokenSource("ts")).WithDualAuth(true)
+		}, sent{auth: "Bearer ts", apiKey: "test-key"}},
+		{"key only", func(c *Config) { c.WithDualAuth(true) }, sent{apiKey: "test-key"}},
+		{"jwt without key", func(c *Config) { c.WithAPIKey("").WithJWTToken("jwt").WithDualAuth(true) }, sent{auth: "Bearer jwt"}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var got sent
+			srv := httptest.NewServer(apiHandler(t, func(w http.ResponseWriter, r *http.Request) {
+				got = sent{r.Header.Get("Authorization"), r.Header.Get("X-API-Key"), r.Header.Get("X-Session-Token")}
+				writeJSON(w, http.StatusOK, map[string]interface{}{})
+			}))
+			defer srv.Close()
+			c := newTestClientFor(t, srv, tc.configure)
+			if _, err := c.Users().GetCurrentUser(context.Background()); err != nil {
+				t.Fatal(err)
+			}
+			if got != tc.want {
+				t.Fatalf("sent %+v, want %+v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestDefaultParams(t *testing.T) {
+	var queries []string
+	srv := httptest.NewServer(apiHandler(t, func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.Method+" "+r.URL.Path+"?"+r.URL.RawQuery)
+		writeJSON(w, http.StatusOK, map[string]interface{}{})
+	}))
+	defer srv.Close()
+	defaults := map[string]interface{}{"limit": 100, "include": "summary"}
+	c := newTestClientFor(t, srv, func(cfg *Config) {
+		cfg.WithDefaultParams(defaults).
+			WithEndpointDefaultParams("/scans", map[string]interface{}{"limit": 25, "status": "completed"})
+	})
+	ctx := context.Background()
+
+	c.Scans().ListScans(ctx, nil)
+	c.Scans().ListScans(ctx, map[string]interface{}{"status": "running"})
+	c.Jobs().ListJobs(ctx, map[string]interface{}{"limit": 5})
+	c.Scans().CreateScan(ctx, map[string]interface{}{"name": "x"})
+
+	want := []string{
+		"GET /scans?include=summary&limit=25&status=completed",
+		"GET /scans?include=summary&limit=25&status=running",
+		"GET /jobs?include=summary&limit=5",
+		"POST /scans?",
+	}
+	if !reflect.DeepEqual(queries, want) {
+		t.Fatalf("requests = %q\nwant %q", queries, want)
+	}
+	if len(defaults) != 2 {
+		t.Fatalf("defaults map mutated: %v", defaults)
+	}
+}
@@ -0,0 +1,49 @@
+package tavo
+
+import (
+	"errors"
+	"net/http"
+	"sync/atomic"
+)
+
+// ErrClientClosed is returned for requests made after Client.Close.
+var ErrClientClosed = errors.New("tavo: client is closed")
+
+// closeState tracks whether a client has been closed.
+type closeState struct {
+	closed atomic.Bool
+	// idle is the client's own transport, nil when the transport is
+	// shared with other clients (see ClientPool).
+	idle *http.Transport
+}
+
+// closedGuard fails every request once the client is closed.
+type closedGuard struct {
+	next  http.RoundTripper
+	state *closeState
+}
+
+func (g *closedGuard) RoundTrip(req *http.Request) (*http.Response, error) {
+	if g.state.closed.Load() {
+		if req.Body != nil {
+			req.Body.Close()
+		}
+		return nil, ErrClientClosed
+	}
+	return g.next.RoundTrip(req)
+}
+
+// Close releases the client's idle connections. The client is unusable
+// afterwards: every request fails with ErrClientClosed. Clients from a
+// ClientPool leave the pool's shared connections open; close those with
+// ClientPool.CloseIdleConnections. Close is idempotent and always returns
+// nil.
+func (c *Client) Close() error {
+	if !c.closer.closed.CompareAndSwap(false, true) {
+		return nil
+	}
+	if c.closer.idle != nil {
+		c.closer.idle.CloseIdleConnections()
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package tavo
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestCloseReleasesConnectionsAndRejectsRequests(t *testing.T) {
+	closed := make(chan struct{}, 1)
+	requests := 0
+	srv := httptest.NewUnstartedServer(apiHandler(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		writeJSON(w, http.StatusOK, map[string]interface{}{"id": "s1"})
+	}))
+	srv.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+		if state == http.StateClosed {
+			select {
+			case closed <- struct{}{}:
+			default:
+			}
+		}
+	}
+	srv.Start()
+	defer srv.Close()
+
+	c := newTestClientFor(t, srv, nil)
+	ctx := context.Background()
+	if _, err := c.Scans().GetScan(ctx, "s1"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := c.Close(); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-closed:
+	case <-time.After(2 * time.Second):
+		t.Fatal("idle connection was not closed")
+	}
+	if err := c.Close(); err != nil {
+		t.Fatalf("second Close = %v", err)
+	}
+
+	if _, err := c.Scans().GetScan(ctx, "s1"); !errors.Is(err, ErrClientClosed) {
+		t.Fatalf("GetScan after Close = %v", err)
+	}
+	if err := c.Reports().DownloadReportTo(ctx, "r1", &bytes.Buffer{}); !errors.Is(err, ErrClientClosed) {
+		t.Fatalf("download after Close = %v", err)
+	}
+	if requests != 1 {
+		t.Fatalf("server saw %d requests, want 1", requests)
+	}
+}
+
+func TestClosePooledClientKeepsSharedTransport(t *testing.T) {
+	srv := httptest.NewServer(apiHandler(t, func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]interface{}{})
+	}))
+	defer srv.Close()
+
+	pool, err := NewClientPool(NewConfig().WithBaseURL(srv.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+	a, _ := pool.Client("key-a")
+	b, _ := pool.Client("key-b")
+	if err := a.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := a.Scans().GetScan(context.Background(), "s1"); !errors.Is(err, ErrClientClosed) {
+		t.Fatalf("closed pooled client: %v", err)
+	}
+	if _, err := b.Scans().GetScan(context.Background(), "s1"); err != nil {
+		t.Fatalf("sibling client: %v", err)
+	}
+}
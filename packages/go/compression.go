@@ -0,0 +1,77 @@
+package tavo
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// encodeBody marshals a JSON request body and, when compression is enabled
+// and the body exceeds the threshold, gzips it. It returns the bytes to send
+// and the Content-Encoding to declare, if any. Bodies over the configured
+// maximum size fail with ErrBodyTooLarge.
+func (c *Client) encodeBody(body interface{}) ([]byte, string, error) {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return nil, "", fmt.Errorf("tavo: encoding request body: %w", err)
+	}
+	if limit := c.maxBodyBytes(); limit >= 0 && int64(len(data)) > limit {
+		return nil, "", bodyTooLarge(limit)
+	}
+	if !c.config.Compression || len(data) <= c.config.CompressionThreshold {
+		return data, "", nil
+	}
+
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(data); err != nil {
+		return nil, "", fmt.Errorf("tavo: compressing request body: %w", err)
+	}
+	if err := zw.Close(); err != nil {
+		return nil, "", fmt.Errorf("tavo: compressing request body: %w", err)
+	}
+	return buf.Bytes(), "gzip", nil
+}
+
+// decodeContentEncoding replaces a gzip-encoded body of a response read
+// without resty's parsing (downloads and streams) with its decoded form.
+// Such bodies only arrive when the request set Accept-Encoding itself; a
+// body net/http already decoded has no Content-Encoding and is left alone.
+// On error the body has been closed.
+func decodeContentEncoding(resp *http.Response) error {
+	if !strings.EqualFold(resp.Header.Get("Content-Encoding"), "gzip") {
+		return nil
+	}
+	zr, err := gzip.NewReader(resp.Body)
+	switch {
+	case errors.Is(err, io.EOF):
+		resp.Body.Close()
+		resp.Body = http.NoBody
+	case err != nil:
+		resp.Body.Close()
+		return fmt.Errorf("decoding gzip response: %w", err)
+	default:
+		resp.Body = &gzipBody{Reader: zr, raw: resp.Body}
+	}
+	resp.Header.Del("Content-Encoding")
+	resp.Header.Del("Content-Length")
+	resp.ContentLength = -1
+	resp.Uncompressed = true
+	return nil
+}
+
+// gzipBody reads a decoded gzip body and closes the underlying one.
+type gzipBody struct {
+	*gzip.Reader
+	raw io.ReadCloser
+}
+
+func (b *gzipBody) Close() error {
+	b.Reader.Close()
+	return b.raw.Close()
+}
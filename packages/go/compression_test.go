@@ -0,0 +1,130 @@
+package tavo
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestCompressionEncodesLargeBodiesOnly(t *testing.T) {
+	var encodings []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Accept-Encoding"); got != "gzip" {
+			t.Errorf("Accept-Encoding = %q", got)
+		}
+		encodings = append(encodings, r.Header.Get("Content-Encoding"))
+
+		var reader io.Reader = r.Body
+		if r.Header.Get("Content-Encoding") == "gzip" {
+			zr, err := gzip.NewReader(r.Body)
+			if err != nil {
+				t.Fatal(err)
+			}
+			reader = zr
+		}
+		var body map[string]interface{}
+		if r.Method == http.MethodPost {
+			if err := json.NewDecoder(reader).Decode(&body); err != nil {
+				t.Errorf("decoding body: %v", err)
+			}
+		}
+
+		// Respond gzip-encoded to check resty decodes it.
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Content-Encoding", "gzip")
+		zw := gzip.NewWriter(w)
+		_ = json.NewEncoder(zw).Encode(map[string]interface{}{"size": len(body)})
+		_ = zw.Close()
+	}))
+	defer srv.Close()
+	c := newTestClientFor(t, srv, func(cfg *Config) {
+		cfg.WithCompression(true).WithCompressionThreshold(64)
+	})
+	ctx := context.Background()
+
+	if _, err := c.Scans().CreateScan(ctx, map[string]interface{}{"name": "small"}); err != nil {
+		t.Fatal(err)
+	}
+	resp, err := c.Scans().CreateScan(ctx, map[string]interface{}{"name": "large", "notes": strings.Repeat("x", 200)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp["size"] != float64(2) {
+		t.Fatalf("resp = %v", resp)
+	}
+	if _, err := c.Scans().GetScan(ctx, "s1"); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"", "gzip", ""}
+	if strings.Join(encodings, ",") != strings.Join(want, ",") {
+		t.Fatalf("Content-Encoding per request = %q, want %q", encodings, want)
+	}
+}
+
+// gzipHandler gzip-encodes body whenever the request accepts gzip.
+func gzipHandler(t *testing.T, contentType, body string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", contentType)
+		if !strings.Contains(r.Header.Get("Accept-Encoding"), "gzip") {
+			io.WriteString(w, body)
+			return
+		}
+		w.Header().Set("Content-Encoding", "gzip")
+		zw := gzip.NewWriter(w)
+		io.WriteString(zw, body)
+		zw.Close()
+	}
+}
+
+func TestCompressionDecodesRawResponses(t *testing.T) {
+	for name, setup := range map[string]func(*Config){
+		"compression": func(cfg *Config) { cfg.WithCompression(true) },
+		// An explicit header turns off net/http's transparent decoding, so
+		// the SDK must decode raw bodies itself.
+		"explicit header": func(cfg *Config) {
+			cfg.WithCompression(true).WithDefaultHeaders(map[string]string{"Accept-Encoding": "gzip"})
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/api/v1/scans/s1/results":
+					gzipHandler(t, "application/json", `{"items":[{"id":"f1"},{"id":"f2"}],"total":2}`)(w, r)
+				case "/api/v1/reports/r1/download":
+					gzipHandler(t, "application/pdf", "%PDF-1.7 report")(w, r)
+				default:
+					t.Errorf("unexpected path %s", r.URL.Path)
+				}
+			}))
+			defer srv.Close()
+			c := newTestClientFor(t, srv, setup)
+			ctx := context.Background()
+
+			var ids []string
+			err := c.Scans().StreamScanResults(ctx, "s1", func(f map[string]interface{}) error {
+				ids = append(ids, f["id"].(string))
+				return nil
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if strings.Join(ids, ",") != "f1,f2" {
+				t.Errorf("ids = %v", ids)
+			}
+
+			var buf strings.Builder
+			if err := c.Reports().DownloadReportTo(ctx, "r1", &buf); err != nil {
+				t.Fatal(err)
+			}
+			if buf.String() != "%PDF-1.7 report" {
+				t.Errorf("report = %q", buf.String())
+			}
+		})
+	}
+}
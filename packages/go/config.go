@@ -0,0 +1,580 @@
+package tavo
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"time"
+)
+
+const (
+	// DefaultBaseURL is the production Tavo AI API host.
+	DefaultBaseURL = "https://api.tavoai.net"
+	// DefaultAPIVersion is the API version used when none is configured.
+	DefaultAPIVersion = "v1"
+	// DefaultTimeout bounds a single HTTP attempt.
+	DefaultTimeout = 30 * time.Second
+	// DefaultMaxRetries is the number of retries after the first attempt.
+	DefaultMaxRetries = 3
+	// DefaultRetryWait is the base delay between retries; it doubles per attempt.
+	DefaultRetryWait = 1 * time.Second
+	// DefaultMaxIdleConns is the default size of the idle connection pool.
+	// Most clients talk to a single host, so the per-host default matches.
+	DefaultMaxIdleConns = 100
+	// DefaultIdleConnTimeout is how long an idle connection is kept open.
+	DefaultIdleConnTimeout = 90 * time.Second
+	// DefaultCompressionThreshold is the request body size, in bytes, above
+	// which bodies are gzip-encoded when compression is enabled.
+	DefaultCompressionThreshold = 1024
+	// DefaultMaxBodyBytes caps JSON request bodies when Config.MaxBodyBytes
+	// is zero.
+	DefaultMaxBodyBytes = 64 << 20
+)
+
+// Environments maps the names accepted by Config.WithEnvironment to their
+// API hosts.
+var Environments = map[string]string{
+	"production": DefaultBaseURL,
+	"staging":    "https://api.staging.tavoai.net",
+	"local":      "http://localhost:3001",
+}
+
+// Config holds the settings used to build a Client.
+//
+// The With* methods set a field on the receiver and return it so calls can
+// be chained. Because they mutate the receiver, use Clone to derive
+// variations from a shared base:
+//
+//	tenant := base.Clone().WithOrganization("t1")
+type Config struct {
+	APIKey         string        `json:"api_key,omitempty"`
+	JWTToken       string        `json:"jwt_token,omitempty"`
+	SessionToken   string        `json:"session_token,omitempty"`
+	BaseURL        string        `json:"base_url,omitempty"`
+	APIVersion     string        `json:"api_version,omitempty"`
+	OrganizationID string        `json:"organization_id,omitempty"`
+	Timeout        time.Duration `json:"timeout,omitempty"`
+	MaxRetries     int           `json:"max_retries"`
+	RetryWait      time.Duration `json:"retry_wait,omitempty"`
+
+	// UserAgent identifies the application. It is sent ahead of the SDK's
+	// own product token in the User-Agent header.
+	UserAgent string `json:"user_agent,omitempty"`
+
+	// MaxAnalyzeBytes caps the file content AnalyzeCodeTyped sends in one
+	// request. Zero means DefaultMaxAnalyzeBytes.
+	MaxAnalyzeBytes int `json:"max_analyze_bytes,omitempty"`
+
+	// MaxBodyBytes caps the size of a JSON request body, including bodies
+	// streamed from an io.Reader; larger requests fail with
+	// ErrBodyTooLarge. Zero means DefaultMaxBodyBytes and a negative value
+	// disables the check. File uploads are not limited.
+	MaxBodyBytes int64 `json:"max_body_bytes,omitempty"`
+
+	// DefaultHeaders are sent with every request. Per-call WithHeader
+	// options override them.
+	DefaultHeaders map[string]string `json:"default_headers,omitempty"`
+
+	// MaxIdleConns, MaxIdleConnsPerHost and IdleConnTimeout tune the
+	// connection pool of the HTTP transport. Zero leaves the transport's
+	// own setting.
+	MaxIdleConns        int           `json:"max_idle_conns,omitempty"`
+	MaxIdleConnsPerHost int           `json:"max_idle_conns_per_host,omitempty"`
+	IdleConnTimeout     time.Duration `json:"idle_conn_timeout,omitempty"`
+
+	// Compression requests gzip responses and gzip-encodes request bodies
+	// larger than CompressionThreshold bytes.
+	Compression          bool `json:"compression,omitempty"`
+	CompressionThreshold int  `json:"compression_threshold,omitempty"`
+
+	// RateLimit caps outgoing requests per second per rate-limit key, with
+	// bursts of up to RateLimitBurst. Zero disables client-side limiting.
+	RateLimit      float64 `json:"rate_limit,omitempty"`
+	RateLimitBurst int     `json:"rate_limit_burst,omitempty"`
+	// RateLimitKeyFunc picks the bucket a request is counted against. When
+	// nil every request shares one bucket.
+	RateLimitKeyFunc func(*http.Request) string `json:"-"`
+
+	// CircuitBreakerThreshold is the number of consecutive failed attempts
+	// (network errors, 429 or 5xx) after which requests are rejected for
+	// CircuitBreakerCooldown. Zero disables the breaker.
+	CircuitBreakerThreshold int           `json:"circuit_breaker_threshold,omitempty"`
+	CircuitBreakerCooldown  time.Duration `json:"circuit_breaker_cooldown,omitempty"`
+
+	// Middlewares wrap every HTTP attempt, first registered outermost.
+	Middlewares []Middleware `json:"-"`
+
+	// TokenSource, when set, supplies the bearer token for every request
+	// and takes precedence over APIKey and JWTToken.
+	TokenSource TokenSource `json:"-"`
+
+	// DefaultParams are query params sent with every GET request, and
+	// EndpointParams those sent with GET requests to one operation path,
+	// such as "/scans". See WithDefaultParams.
+	DefaultParams  map[string]interface{}            `json:"default_params,omitempty"`
+	EndpointParams map[string]map[string]interface{} `json:"endpoint_params,omitempty"`
+
+	// DualAuth sends APIKey alongside the user credential instead of only
+	// the one with the highest precedence. See WithDualAuth.
+	DualAuth bool `json:"dual_auth,omitempty"`
+
+	// Metrics, when set, observes every HTTP attempt.
+	Metrics Metrics `json:"-"`
+
+	// UserCacheTTL is how long GetCurrentUser reuses its last result. Zero
+	// disables caching.
+	UserCacheTTL time.Duration `json:"user_cache_ttl,omitempty"`
+
+	// RetryHook, when set, is called before every retry.
+	RetryHook RetryHook `json:"-"`
+
+	// Logger receives debug messages about requests and retries.
+	Logger func(format string, args ...interface{}) `json:"-"`
+
+	// RuleSchema replaces the JSON schema bundled with the SDK for
+	// ScanRuleOperations.ValidateSchema.
+	RuleSchema []byte `json:"-"`
+
+	// Slog receives structured records of requests, retries and errors.
+	// Nil discards them.
+	Slog *slog.Logger `json:"-"`
+
+	// RecordDir, when set, records every HTTP exchange to files in the
+	// directory, and ReplayDir answers requests from such files instead of
+	// the network. See WithRecording and WithReplay.
+	RecordDir string `json:"-"`
+	ReplayDir string `json:"-"`
+
+	// Debug records the last DebugLogSize raw HTTP exchanges for
+	// Client.DebugLog.
+	Debug        bool `json:"debug,omitempty"`
+	DebugLogSize int  `json:"debug_log_size,omitempty"`
+
+	// err is a configuration error from a With* method that cannot return
+	// one, reported by Err and Validate.
+	err error
+}
+
+// NewConfig returns a Config with defaults applied and settings read from
+// TAVO_API_KEY, TAVO_JWT_TOKEN, TAVO_SESSION_TOKEN, TAVO_BASE_URL,
+// TAVO_ORGANIZATION_ID, TAVO_REQUEST_TIMEOUT (a duration such as "45s")
+// and TAVO_MAX_RETRIES (an integer).
+//
+// Environment variables override the defaults, and With* calls made on the
+// result override both. A TAVO_REQUEST_TIMEOUT or TAVO_MAX_RETRIES that
+// cannot be parsed is logged as a warning and ignored.
+func NewConfig() *Config {
+	c := defaultConfig()
+	c.applyEnv()
+	return c
+}
+
+func defaultConfig() *Config {
+	return &Config{
+		BaseURL:    DefaultBaseURL,
+		APIVersion: DefaultAPIVersion,
+		Timeout:    DefaultTimeout,
+		MaxRetries: DefaultMaxRetries,
+		RetryWait:  DefaultRetryWait,
+
+		MaxIdleConns:        DefaultMaxIdleConns,
+		MaxIdleConnsPerHost: DefaultMaxIdleConns,
+		IdleConnTimeout:     DefaultIdleConnTimeout,
+
+		CompressionThreshold: DefaultCompressionThreshold,
+	}
+}
+
+// applyEnv overrides c with the TAVO_* environment variables that are set.
+func (c *Config) applyEnv() {
+	if v := os.Getenv("TAVO_API_KEY"); v != "" {
+		c.APIKey = v
+	}
+	if v := os.Getenv("TAVO_JWT_TOKEN"); v != "" {
+		c.JWTToken = v
+	}
+	if v := os.Getenv("TAVO_SESSION_TOKEN"); v != "" {
+		c.SessionToken = v
+	}
+	if v := os.Getenv("TAVO_BASE_URL"); v != "" {
+		c.BaseURL = v
+	}
+	if v := os.Getenv("TAVO_ORGANIZATION_ID"); v != "" {
+		c.OrganizationID = v
+	}
+	if v := os.Getenv("TAVO_REQUEST_TIMEOUT"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil && d > 0 {
+			c.Timeout = d
+		} else {
+			log.Printf("tavo: warning: ignoring TAVO_REQUEST_TIMEOUT=%q: want a positive duration such as 30s", v)
+		}
+	}
+	if v := os.Getenv("TAVO_MAX_RETRIES"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n >= 0 {
+			c.MaxRetries = n
+		} else {
+			log.Printf("tavo: warning: ignoring TAVO_MAX_RETRIES=%q: want a non-negative integer", v)
+		}
+	}
+}
+
+// Clone returns an independent copy of c. Maps and slices are copied, so
+// changing the clone, through With* methods or directly, never affects c.
+// Interface and function values such as TokenSource, Metrics and Logger
+// are shared.
+func (c *Config) Clone() *Config {
+	clone := *c
+	if c.DefaultHeaders != nil {
+		clone.DefaultHeaders = make(map[string]string, len(c.DefaultHeaders))
+		for k, v := range c.DefaultHeaders {
+			clone.DefaultHeaders[k] = v
+		}
+	}
+	if c.Middlewares != nil {
+		clone.Middlewares = append([]Middleware(nil), c.Middlewares...)
+	}
+	if c.DefaultParams != nil {
+		clone.DefaultParams = copyParams(c.DefaultParams)
+	}
+	if c.EndpointParams != nil {
+		clone.EndpointParams = make(map[string]map[string]interface{}, len(c.EndpointParams))
+		for path, params := range c.EndpointParams {
+			clone.EndpointParams[path] = copyParams(params)
+		}
+	}
+	if c.RuleSchema != nil {
+		clone.RuleSchema = append([]byte(nil), c.RuleSchema...)
+	}
+	return &clone
+}
+
+// WithAPIKey sets the API key sent as X-API-Key.
+func (c *Config) WithAPIKey(apiKey string) *Config {
+	c.APIKey = apiKey
+	return c
+}
+
+// WithJWTToken sets the bearer token sent in the Authorization header.
+func (c *Config) WithJWTToken(token string) *Config {
+	c.JWTToken = token
+	return c
+}
+
+// WithSessionToken sets the session token sent as X-Session-Token. It is
+// used when no token source or JWT is configured, and takes precedence
+// over the API key.
+func (c *Config) WithSessionToken(token string) *Config {
+	c.SessionToken = token
+	return c
+}
+
+// WithBaseURL sets the API host. It may include a path prefix, such as
+// https://gateway.internal/tavo/ behind a reverse proxy; requests are then
+// sent under that prefix.
+func (c *Config) WithBaseURL(baseURL string) *Config {
+	c.BaseURL = baseURL
+	return c
+}
+
+// WithEnvironment sets BaseURL to the host of a named environment:
+// "production", "staging" or "local" (see Environments).
+//
+// An unknown name leaves BaseURL unchanged and records an error. Because
+// the method returns the Config for chaining, the error is not returned
+// here: check Err right after the call, or rely on Validate, and so
+// NewClient, failing with it.
+//
+//	cfg := tavo.NewConfig().WithEnvironment(os.Getenv("TAVO_ENV"))
+//	if err := cfg.Err(); err != nil {
+//		...
+//	}
+func (c *Config) WithEnvironment(env string) *Config {
+	baseURL, ok := Environments[env]
+	if !ok {
+		c.err = fmt.Errorf("tavo: unknown environment %q (want production, staging or local)", env)
+		return c
+	}
+	c.err = nil
+	c.BaseURL = baseURL
+	return c
+}
+
+// WithAPIVersion selects the API version, such as "v1" or "v2". Requests
+// are sent under /api/{version}; an empty version sends them to the base
+// URL as is.
+func (c *Config) WithAPIVersion(version string) *Config {
+	c.APIVersion = version
+	return c
+}
+
+// WithOrganization scopes requests to an organization via X-Organization-ID.
+func (c *Config) WithOrganization(orgID string) *Config {
+	c.OrganizationID = orgID
+	return c
+}
+
+// WithUserAgent sets the application's product token, such as
+// "acme-ci/2.3". Requests send it followed by the SDK version and Go
+// runtime, e.g. "acme-ci/2.3 tavo-go-sdk/0.4.0 go1.21.5".
+func (c *Config) WithUserAgent(ua string) *Config {
+	c.UserAgent = ua
+	return c
+}
+
+// WithMaxAnalyzeBytes sets the largest total file content AnalyzeCodeTyped
+// will send.
+func (c *Config) WithMaxAnalyzeBytes(n int) *Config {
+	c.MaxAnalyzeBytes = n
+	return c
+}
+
+// WithMaxBodyBytes sets the largest JSON request body the client will
+// send. A negative n disables the limit.
+func (c *Config) WithMaxBodyBytes(n int64) *Config {
+	c.MaxBodyBytes = n
+	return c
+}
+
+// WithDefaultHeaders adds headers sent with every request.
+func (c *Config) WithDefaultHeaders(headers map[string]string) *Config {
+	if c.DefaultHeaders == nil {
+		c.DefaultHeaders = make(map[string]string, len(headers))
+	}
+	for k, v := range headers {
+		c.DefaultHeaders[k] = v
+	}
+	return c
+}
+
+// WithTimeout sets the per-attempt HTTP timeout.
+func (c *Config) WithTimeout(timeout time.Duration) *Config {
+	c.Timeout = timeout
+	return c
+}
+
+// WithMaxRetries sets how many times a failed request is retried.
+func (c *Config) WithMaxRetries(n int) *Config {
+	c.MaxRetries = n
+	return c
+}
+
+// WithRetryWait sets the base delay between retries.
+func (c *Config) WithRetryWait(d time.Duration) *Config {
+	c.RetryWait = d
+	return c
+}
+
+// WithConnectionPool tunes connection reuse: the total number of idle
+// connections kept, the number kept per host, and how long an idle
+// connection stays open. Raise them when running many concurrent requests
+// against the API to avoid repeated TLS handshakes.
+func (c *Config) WithConnectionPool(maxIdleConns, maxIdlePerHost int, idleTimeout time.Duration) *Config {
+	c.MaxIdleConns = maxIdleConns
+	c.MaxIdleConnsPerHost = maxIdlePerHost
+	c.IdleConnTimeout = idleTimeout
+	return c
+}
+
+// WithCompression enables gzip for responses and for request bodies above
+// the compression threshold. GET requests and small bodies are sent as is.
+func (c *Config) WithCompression(enabled bool) *Config {
+	c.Compression = enabled
+	return c
+}
+
+// WithCompressionThreshold sets the request body size, in bytes, above which
+// bodies are compressed.
+func (c *Config) WithCompressionThreshold(bytes int) *Config {
+	c.CompressionThreshold = bytes
+	return c
+}
+
+// WithRateLimit limits the client to requestsPerSecond, allowing bursts of
+// up to burst requests. Requests wait for a token rather than failing.
+func (c *Config) WithRateLimit(requestsPerSecond float64, burst int) *Config {
+	c.RateLimit = requestsPerSecond
+	c.RateLimitBurst = burst
+	return c
+}
+
+// WithRateLimitKeyFunc gives each key returned by fn its own rate-limit
+// bucket, so one busy tenant of a shared client does not throttle others.
+// fn may inspect the request's headers and context.
+func (c *Config) WithRateLimitKeyFunc(fn func(*http.Request) string) *Config {
+	c.RateLimitKeyFunc = fn
+	return c
+}
+
+// WithCircuitBreaker makes the client fail fast with a TavoError coded
+// CodeCircuitOpen after threshold consecutive failures, until cooldown has
+// elapsed. A single probe request is then let through to test recovery. The
+// breaker is shared by all operations and goroutines using the client.
+func (c *Config) WithCircuitBreaker(threshold int, cooldown time.Duration) *Config {
+	c.CircuitBreakerThreshold = threshold
+	c.CircuitBreakerCooldown = cooldown
+	return c
+}
+
+// WithRoundTripper registers a middleware around each HTTP attempt.
+// Middlewares compose in registration order: the first one registered sees
+// the request first and the response (or error) last.
+func (c *Config) WithRoundTripper(mw Middleware) *Config {
+	c.Middlewares = append(c.Middlewares, mw)
+	return c
+}
+
+// WithDefaultParams sets query params merged into every GET request, such
+// as {"limit": 100} for list calls. Servers ignore params an endpoint does
+// not use. Params passed to a call override endpoint defaults, which
+// override these. Iterators and the ListAll helpers pass their own limit
+// (DefaultPageSize) unless given one, so a default limit does not change
+// their page size.
+func (c *Config) WithDefaultParams(params map[string]interface{}) *Config {
+	c.DefaultParams = params
+	return c
+}
+
+// WithEndpointDefaultParams sets query params merged into GET requests to
+// one operation path, such as "/scans" for ListScans, overriding
+// WithDefaultParams for that path.
+func (c *Config) WithEndpointDefaultParams(path string, params map[string]interface{}) *Config {
+	if c.EndpointParams == nil {
+		c.EndpointParams = make(map[string]map[string]interface{})
+	}
+	c.EndpointParams[path] = params
+	return c
+}
+
+// WithDualAuth controls whether an API key is sent together with a user
+// credential. Normally a client sends exactly one credential, the first
+// configured of: TokenSource, JWTToken (both as a bearer token),
+// SessionToken, APIKey. With dual auth enabled and an APIKey set, X-API-Key
+// is sent on every request in addition to that user credential, and the
+// server decides per endpoint which one to honour: a user-scoped endpoint
+// acts as the user, a service endpoint as the key's service account. When
+// only one credential is configured dual auth changes nothing.
+func (c *Config) WithDualAuth(enabled bool) *Config {
+	c.DualAuth = enabled
+	return c
+}
+
+// WithTokenSource makes the client ask ts for a bearer token before each
+// request, so rotated credentials are picked up without a new client.
+func (c *Config) WithTokenSource(ts TokenSource) *Config {
+	c.TokenSource = ts
+	return c
+}
+
+// WithMetrics reports every HTTP attempt, including retries and network
+// failures, to m.
+func (c *Config) WithMetrics(m Metrics) *Config {
+	c.Metrics = m
+	return c
+}
+
+// WithUserCache makes GetCurrentUser reuse its result for ttl, which
+// suits handlers that check the caller's roles on every request. The cache
+// is dropped by UpdateProfile, Logout and Client.InvalidateUserCache.
+func (c *Config) WithUserCache(ttl time.Duration) *Config {
+	c.UserCacheTTL = ttl
+	return c
+}
+
+// WithRetryHook calls hook before every retry of every operation, with
+// why the previous attempt failed and how long the client will wait. Use
+// it to count retry storms or correlate latency with upstream incidents.
+func (c *Config) WithRetryHook(hook RetryHook) *Config {
+	c.RetryHook = hook
+	return c
+}
+
+// WithLogger sets a printf-style debug logger.
+func (c *Config) WithLogger(logger func(format string, args ...interface{})) *Config {
+	c.Logger = logger
+	return c
+}
+
+// WithRuleSchema makes ScanRuleOperations.ValidateSchema check rules
+// against schema, a JSON schema document, instead of the bundled one. Use
+// it for custom rule formats. Only the type, required, properties,
+// additionalProperties, items, enum, pattern, minLength, maxLength,
+// minimum, maximum, minItems and maxItems keywords are enforced, and
+// annotations such as title and description are allowed. Validate rejects
+// a schema that is not valid JSON or uses any other keyword.
+func (c *Config) WithRuleSchema(schema []byte) *Config {
+	c.RuleSchema = schema
+	return c
+}
+
+// WithSlog sends structured records of every request attempt, retry and
+// error to logger, with method, path, status, duration_ms and attempt
+// attributes. Successful attempts are logged at debug level together with
+// the request headers; credential headers are redacted.
+func (c *Config) WithSlog(logger *slog.Logger) *Config {
+	c.Slog = logger
+	return c
+}
+
+// WithRecording writes every HTTP exchange the client makes to a file in
+// dir, with credential headers redacted, through a RecordingTransport.
+// Replay the recordings in tests with WithReplay.
+func (c *Config) WithRecording(dir string) *Config {
+	c.RecordDir = dir
+	return c
+}
+
+// WithReplay answers every request from the recordings in dir through a
+// ReplayTransport, so no network access happens. Requests without a
+// recording fail with ErrNoRecording. It takes precedence over
+// WithRecording.
+func (c *Config) WithReplay(dir string) *Config {
+	c.ReplayDir = dir
+	return c
+}
+
+// WithDebug records raw requests and responses so they can be read back
+// with Client.DebugLog. Credential headers and sensitive JSON fields such
+// as passwords, tokens and secrets are redacted; only JSON bodies up to
+// 64 KiB are shown, so uploads, downloads and event streams are not
+// buffered. When a Logger is set, resty's debug output is also sent to it.
+func (c *Config) WithDebug(enabled bool) *Config {
+	c.Debug = enabled
+	return c
+}
+
+// Err returns the error recorded by a With* method that cannot return one,
+// such as WithEnvironment with an unknown name, or nil. Validate reports
+// the same error.
+func (c *Config) Err() error {
+	return c.err
+}
+
+// Validate reports whether the configuration can be used to build a client.
+func (c *Config) Validate() error {
+	if c.err != nil {
+		return c.err
+	}
+	if c.APIKey == "" && c.JWTToken == "" && c.SessionToken == "" && c.TokenSource == nil {
+		return errors.New("tavo: an API key, JWT token, session token or token source is required")
+	}
+	if c.BaseURL == "" {
+		return errors.New("tavo: base URL is required")
+	}
+	if u, err := url.Parse(c.BaseURL); err != nil || u.Scheme == "" || u.Host == "" {
+		return fmt.Errorf("tavo: base URL %q must be an absolute URL such as https://api.tavoai.net", c.BaseURL)
+	}
+	if c.MaxRetries < 0 {
+		return errors.New("tavo: max retries must not be negative")
+	}
+	if len(c.RuleSchema) > 0 {
+		if _, err := compileSchema(c.RuleSchema); err != nil {
+			return err
+		}
+	}
+	return nil
+}
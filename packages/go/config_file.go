@@ -0,0 +1,158 @@
+package tavo
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// LoadConfigFromFile reads a Config from a JSON (.json) or YAML (.yaml,
+// .yml) file whose keys are the Config JSON tags, such as base_url or
+// max_retries, in the form Config's MarshalJSON writes. Durations may be
+// written as strings ("30s") or as numbers of seconds. Defaults fill keys
+// the file omits, the TAVO_* environment
+// variables read by NewConfig override the file, and the result is
+// validated. Unknown keys are an error.
+func LoadConfigFromFile(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("tavo: reading config: %w", err)
+	}
+
+	var raw map[string]interface{}
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".json":
+		err = json.Unmarshal(data, &raw)
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &raw)
+	default:
+		return nil, fmt.Errorf("tavo: config file %s: unsupported extension %q (want .json, .yaml or .yml)", path, ext)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("tavo: parsing config file %s: %w", path, err)
+	}
+
+	cfg := defaultConfig()
+	if err := applyConfigMap(cfg, raw); err != nil {
+		return nil, fmt.Errorf("tavo: config file %s: %w", path, err)
+	}
+	cfg.applyEnv()
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// applyConfigMap sets the fields of cfg named by the keys of raw.
+func applyConfigMap(cfg *Config, raw map[string]interface{}) error {
+	fields := configFields()
+	var unknown []string
+	for key, v := range raw {
+		field, ok := fields[key]
+		if !ok {
+			unknown = append(unknown, key)
+			continue
+		}
+		if field.Type == durationType {
+			d, err := parseConfigDuration(v)
+			if err != nil {
+				return fmt.Errorf("%s: %w", key, err)
+			}
+			raw[key] = d.String()
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return fmt.Errorf("unknown keys %s", strings.Join(unknown, ", "))
+	}
+	return decodeMap(raw, cfg)
+}
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// configJSON has Config's fields without its JSON methods.
+type configJSON Config
+
+// MarshalJSON encodes c with its JSON tags, writing durations as strings
+// such as "30s" so the output can be read back by LoadConfigFromFile and
+// UnmarshalJSON.
+func (c Config) MarshalJSON() ([]byte, error) {
+	data, err := json.Marshal(configJSON(c))
+	if err != nil {
+		return nil, err
+	}
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+	v := reflect.ValueOf(c)
+	for key, field := range configFields() {
+		if _, ok := raw[key]; ok && field.Type == durationType {
+			raw[key], _ = json.Marshal(time.Duration(v.FieldByIndex(field.Index).Int()).String())
+		}
+	}
+	return json.Marshal(raw)
+}
+
+// UnmarshalJSON decodes c from the form MarshalJSON writes. As in
+// LoadConfigFromFile, durations may also be numbers of seconds.
+func (c *Config) UnmarshalJSON(data []byte) error {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	for key, field := range configFields() {
+		if _, ok := raw[key]; !ok || field.Type != durationType {
+			continue
+		}
+		var v interface{}
+		if err := json.Unmarshal(raw[key], &v); err != nil {
+			return err
+		}
+		if v == nil {
+			continue
+		}
+		d, err := parseConfigDuration(v)
+		if err != nil {
+			return fmt.Errorf("tavo: config %s: %w", key, err)
+		}
+		raw[key], _ = json.Marshal(int64(d))
+	}
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, (*configJSON)(c))
+}
+
+// configFields maps each Config JSON key to its struct field.
+func configFields() map[string]reflect.StructField {
+	t := reflect.TypeOf(Config{})
+	fields := make(map[string]reflect.StructField, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if name != "" && name != "-" {
+			fields[name] = t.Field(i)
+		}
+	}
+	return fields
+}
+
+func parseConfigDuration(v interface{}) (time.Duration, error) {
+	switch v := v.(type) {
+	case string:
+		return time.ParseDuration(v)
+	case int:
+		return time.Duration(v) * time.Second, nil
+	case float64:
+		return time.Duration(v * float64(time.Second)), nil
+	}
+	return 0, fmt.Errorf("invalid duration %v", v)
+}
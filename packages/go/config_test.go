@@ -0,0 +1,255 @@
+package tavo
+
+import (
+	"bytes"
+	"encoding/json"
+	"log"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewConfigReadsEnvironment(t *testing.T) {
+	t.Setenv("TAVO_API_KEY", "env-key")
+	t.Setenv("TAVO_BASE_URL", "https://example.test")
+
+	cfg := NewConfig()
+	if cfg.APIKey != "env-key" || cfg.BaseURL != "https://example.test" {
+		t.Fatalf("cfg = %+v", cfg)
+	}
+	if cfg.APIVersion != DefaultAPIVersion || cfg.MaxRetries != DefaultMaxRetries {
+		t.Fatalf("defaults not applied: %+v", cfg)
+	}
+}
+
+func TestNewConfigTimeoutAndRetriesFromEnvironment(t *testing.T) {
+	t.Setenv("TAVO_REQUEST_TIMEOUT", "45s")
+	t.Setenv("TAVO_MAX_RETRIES", "0")
+
+	cfg := NewConfig()
+	if cfg.Timeout != 45*time.Second || cfg.MaxRetries != 0 {
+		t.Fatalf("timeout = %s, retries = %d", cfg.Timeout, cfg.MaxRetries)
+	}
+	// Explicit settings win over the environment.
+	if cfg := NewConfig().WithTimeout(time.Second).WithMaxRetries(5); cfg.Timeout != time.Second || cfg.MaxRetries != 5 {
+		t.Fatalf("timeout = %s, retries = %d", cfg.Timeout, cfg.MaxRetries)
+	}
+}
+
+func TestNewConfigInvalidEnvironmentFallsBack(t *testing.T) {
+	var logged bytes.Buffer
+	log.SetOutput(&logged)
+	defer log.SetOutput(os.Stderr)
+
+	for _, tc := range []struct{ timeout, retries string }{
+		{"soon", "many"},
+		{"-5s", "-1"},
+		{"30", "1.5"},
+	} {
+		logged.Reset()
+		t.Setenv("TAVO_REQUEST_TIMEOUT", tc.timeout)
+		t.Setenv("TAVO_MAX_RETRIES", tc.retries)
+
+		cfg := NewConfig()
+		if cfg.Timeout != DefaultTimeout || cfg.MaxRetries != DefaultMaxRetries {
+			t.Errorf("%+v: timeout = %s, retries = %d", tc, cfg.Timeout, cfg.MaxRetries)
+		}
+		out := logged.String()
+		if !strings.Contains(out, "TAVO_REQUEST_TIMEOUT") || !strings.Contains(out, "TAVO_MAX_RETRIES") {
+			t.Errorf("%+v: warnings = %q", tc, out)
+		}
+	}
+}
+
+func TestConfigValidateRequiresCredentials(t *testing.T) {
+	t.Setenv("TAVO_API_KEY", "")
+	t.Setenv("TAVO_JWT_TOKEN", "")
+
+	if err := NewConfig().Validate(); err == nil {
+		t.Fatal("expected error without credentials")
+	}
+	if err := NewConfig().WithAPIKey("k").Validate(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func writeConfigFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadConfigFromFileYAML(t *testing.T) {
+	t.Setenv("TAVO_API_KEY", "env-secret")
+	path := writeConfigFile(t, "tavo.yaml", `
+api_key: file-key
+base_url: https://tavo.internal
+organization_id: org-1
+timeout: 45s
+retry_wait: 2
+max_retries: 5
+`)
+
+	cfg, err := LoadConfigFromFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.APIKey != "env-secret" {
+		t.Errorf("APIKey = %q, env should override the file", cfg.APIKey)
+	}
+	if cfg.BaseURL != "https://tavo.internal" || cfg.OrganizationID != "org-1" || cfg.MaxRetries != 5 {
+		t.Errorf("cfg = %+v", cfg)
+	}
+	if cfg.Timeout != 45*time.Second || cfg.RetryWait != 2*time.Second {
+		t.Errorf("Timeout = %s, RetryWait = %s", cfg.Timeout, cfg.RetryWait)
+	}
+	if cfg.APIVersion != DefaultAPIVersion {
+		t.Errorf("defaults not applied: %+v", cfg)
+	}
+}
+
+func TestLoadConfigFromFileJSON(t *testing.T) {
+	t.Setenv("TAVO_API_KEY", "")
+	path := writeConfigFile(t, "tavo.json", `{"jwt_token": "jwt", "timeout": "1m"}`)
+
+	cfg, err := LoadConfigFromFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.JWTToken != "jwt" || cfg.Timeout != time.Minute || cfg.BaseURL != DefaultBaseURL {
+		t.Fatalf("cfg = %+v", cfg)
+	}
+}
+
+func TestLoadConfigFromFileErrors(t *testing.T) {
+	t.Setenv("TAVO_API_KEY", "k")
+	cases := map[string]struct{ name, content, want string }{
+		"unknown key":   {"tavo.yaml", "api_key: k\nbase_ulr: x\nretries: 2\n", "unknown keys base_ulr, retries"},
+		"bad duration":  {"tavo.yaml", "timeout: soon\n", "timeout"},
+		"extension":     {"tavo.toml", "", "unsupported extension"},
+		"invalid json":  {"tavo.json", "{", "parsing config file"},
+		"invalid value": {"tavo.json", `{"max_retries": -1}`, "max retries"},
+	}
+	for name, tc := range cases {
+		_, err := LoadConfigFromFile(writeConfigFile(t, tc.name, tc.content))
+		if err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("%s: err = %v, want it to mention %q", name, err, tc.want)
+		}
+	}
+}
+
+func TestValidateRejectsRelativeBaseURL(t *testing.T) {
+	for _, base := range []string{"api.tavoai.net", "/tavo", "://bad"} {
+		if err := NewConfig().WithAPIKey("k").WithBaseURL(base).Validate(); err == nil {
+			t.Errorf("Validate accepted base URL %q", base)
+		}
+	}
+}
+
+func TestConfigCloneIsIndependent(t *testing.T) {
+	noop := func(next RoundTripFunc) RoundTripFunc { return next }
+	base := NewConfig().
+		WithAPIKey("k").
+		WithOrganization("base").
+		WithDefaultHeaders(map[string]string{"X-Team": "core"}).
+		WithRoundTripper(noop)
+
+	tenant := base.Clone().
+		WithOrganization("t1").
+		WithDefaultHeaders(map[string]string{"X-Team": "t1", "X-Tenant": "t1"}).
+		WithRoundTripper(noop)
+	tenant.MaxRetries = 9
+
+	if base.OrganizationID != "base" || base.MaxRetries != DefaultMaxRetries {
+		t.Errorf("base scalars changed: %+v", base)
+	}
+	if len(base.DefaultHeaders) != 1 || base.DefaultHeaders["X-Team"] != "core" {
+		t.Errorf("base headers changed: %v", base.DefaultHeaders)
+	}
+	if len(base.Middlewares) != 1 || len(tenant.Middlewares) != 2 {
+		t.Errorf("middlewares: base %d, tenant %d", len(base.Middlewares), len(tenant.Middlewares))
+	}
+	if tenant.APIKey != "k" || tenant.DefaultHeaders["X-Tenant"] != "t1" {
+		t.Errorf("tenant = %+v", tenant)
+	}
+
+	// Every map and slice field must be copied, including ones added later.
+	bv, cv := reflect.ValueOf(base).Elem(), reflect.ValueOf(base.Clone()).Elem()
+	for i := 0; i < bv.NumField(); i++ {
+		switch bv.Field(i).Kind() {
+		case reflect.Map, reflect.Slice:
+			if !bv.Field(i).IsNil() && bv.Field(i).Pointer() == cv.Field(i).Pointer() {
+				t.Errorf("Clone shares %s", bv.Type().Field(i).Name)
+			}
+		}
+	}
+}
+
+func TestWithEnvironment(t *testing.T) {
+	for env, want := range map[string]string{
+		"production": "https://api.tavoai.net",
+		"staging":    "https://api.staging.tavoai.net",
+		"local":      "http://localhost:3001",
+	} {
+		cfg := NewConfig().WithAPIKey("k").WithEnvironment(env)
+		if cfg.BaseURL != want {
+			t.Errorf("%s: BaseURL = %q, want %q", env, cfg.BaseURL, want)
+		}
+		if err := cfg.Validate(); err != nil {
+			t.Errorf("%s: Validate() = %v", env, err)
+		}
+	}
+
+	cfg := NewConfig().WithAPIKey("k").WithBaseURL("https://custom.example").WithEnvironment("stagin")
+	if cfg.BaseURL != "https://custom.example" {
+		t.Fatalf("unknown environment changed BaseURL to %q", cfg.BaseURL)
+	}
+	if err := cfg.Err(); err == nil || !strings.Contains(err.Error(), `unknown environment "stagin"`) {
+		t.Fatalf("Err() = %v", err)
+	}
+	if _, err := NewClient(cfg); err == nil || !strings.Contains(err.Error(), `unknown environment "stagin"`) {
+		t.Fatalf("NewClient err = %v", err)
+	}
+	// A later valid environment clears the error.
+	if err := cfg.WithEnvironment("staging").Validate(); err != nil || cfg.Err() != nil {
+		t.Fatalf("Validate() after fixing = %v, Err() = %v", err, cfg.Err())
+	}
+}
+
+func TestConfigJSONRoundTrip(t *testing.T) {
+	t.Setenv("TAVO_API_KEY", "")
+	cfg := NewConfig().WithJWTToken("jwt").WithTimeout(45*time.Second).WithRetryWait(1500*time.Millisecond).
+		WithCircuitBreaker(3, time.Minute)
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), `"timeout":"45s"`) || !strings.Contains(string(data), `"retry_wait":"1.5s"`) {
+		t.Fatalf("durations not written as strings: %s", data)
+	}
+
+	loaded, err := LoadConfigFromFile(writeConfigFile(t, "tavo.json", string(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded Config
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	for _, got := range []*Config{loaded, &decoded} {
+		if got.Timeout != cfg.Timeout || got.RetryWait != cfg.RetryWait || got.CircuitBreakerCooldown != time.Minute ||
+			got.IdleConnTimeout != cfg.IdleConnTimeout || got.JWTToken != "jwt" || got.CircuitBreakerThreshold != 3 {
+			t.Fatalf("round trip = %+v, want %+v", got, cfg)
+		}
+	}
+
+	if err := json.Unmarshal([]byte(`{"timeout": 30}`), &decoded); err != nil || decoded.Timeout != 30*time.Second {
+		t.Fatalf("numeric seconds: Timeout = %s, err = %v", decoded.Timeout, err)
+	}
+}
@@ -0,0 +1,315 @@
+package tavo
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/http/httputil"
+	"strings"
+	"sync"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// DefaultDebugLogSize is the number of exchanges DebugLog keeps when debug
+// mode is enabled without an explicit size.
+const DefaultDebugLogSize = 20
+
+// redactedHeaders carry credentials and are masked in debug output.
+var redactedHeaders = []string{"Authorization", "X-API-Key", "X-Session-Token", "Cookie", "Set-Cookie"}
+
+const redacted = "[REDACTED]"
+
+// debugLog is a fixed-size ring of request/response dumps.
+type debugLog struct {
+	mu      sync.Mutex
+	entries []string
+	next    int
+	full    bool
+}
+
+func newDebugLog(size int) *debugLog {
+	if size <= 0 {
+		size = DefaultDebugLogSize
+	}
+	return &debugLog{entries: make([]string, size)}
+}
+
+func (l *debugLog) add(entry string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.entries[l.next] = entry
+	l.next = (l.next + 1) % len(l.entries)
+	l.full = l.full || l.next == 0
+}
+
+// snapshot returns the entries oldest first.
+func (l *debugLog) snapshot() []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if !l.full {
+		return append([]string(nil), l.entries[:l.next]...)
+	}
+	out := make([]string, 0, len(l.entries))
+	out = append(out, l.entries[l.next:]...)
+	return append(out, l.entries[:l.next]...)
+}
+
+// debugMaxBody is the largest body shown in debug output. Larger bodies,
+// and bodies that are not JSON, are omitted so that uploads, downloads and
+// event streams are neither buffered nor held up for the log.
+const debugMaxBody = 64 << 10
+
+// debugTransport records every exchange it carries into log.
+type debugTransport struct {
+	next http.RoundTripper
+	log  *debugLog
+}
+
+func (t *debugTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var b strings.Builder
+	if dump, err := httputil.DumpRequestOut(req, false); err == nil {
+		b.Write(redactDump(dump))
+		b.WriteString(debugRequestBody(req))
+	} else {
+		fmt.Fprintf(&b, "%s %s\n(request dump failed: %v)\n", req.Method, req.URL, err)
+	}
+
+	resp, err := t.next.RoundTrip(req)
+	b.WriteString("\n\n")
+	if err != nil {
+		fmt.Fprintf(&b, "error: %v", err)
+	} else if dump, derr := httputil.DumpResponse(resp, false); derr == nil {
+		b.Write(redactDump(dump))
+		b.WriteString(debugResponseBody(resp))
+	} else {
+		fmt.Fprintf(&b, "%s\n(response dump failed: %v)", resp.Status, derr)
+	}
+	t.log.add(b.String())
+	return resp, err
+}
+
+// debugRequestBody returns the redacted request body for the log. Only
+// small JSON bodies that can be re-read through GetBody are shown, so a
+// streamed body is never consumed.
+func debugRequestBody(req *http.Request) string {
+	if req.Body == nil || req.Body == http.NoBody {
+		return ""
+	}
+	if reason := debugSkipReason(req.Header, req.ContentLength); reason != "" {
+		return omittedBody(reason)
+	}
+	if req.GetBody == nil {
+		return omittedBody("streamed")
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return omittedBody(err.Error())
+	}
+	defer body.Close()
+	data, err := io.ReadAll(io.LimitReader(body, debugMaxBody))
+	if err != nil {
+		return omittedBody(err.Error())
+	}
+	return redactJSONBody(data, true)
+}
+
+// debugResponseBody returns the redacted response body for the log,
+// reading at most debugMaxBody bytes. What was read is put back in front
+// of the rest of the body for the caller.
+func debugResponseBody(resp *http.Response) string {
+	if resp.Body == nil || resp.Body == http.NoBody {
+		return ""
+	}
+	if reason := debugSkipReason(resp.Header, resp.ContentLength); reason != "" {
+		return omittedBody(reason)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, debugMaxBody+1))
+	resp.Body = struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(data), resp.Body), resp.Body}
+	switch {
+	case err != nil:
+		return omittedBody(err.Error())
+	case len(data) > debugMaxBody:
+		return omittedBody(fmt.Sprintf("over %d bytes", debugMaxBody))
+	}
+	return redactJSONBody(data, false)
+}
+
+// debugSkipReason says why a body with header h and the given length is
+// not shown, or returns "" if it may be.
+func debugSkipReason(h http.Header, length int64) string {
+	mediaType, _, _ := mime.ParseMediaType(h.Get("Content-Type"))
+	switch {
+	case mediaType != "application/json" && !strings.HasSuffix(mediaType, "+json"):
+		if mediaType == "" {
+			mediaType = "unknown type"
+		}
+		return mediaType
+	case h.Get("Content-Encoding") != "":
+		return h.Get("Content-Encoding") + " encoded"
+	case length > debugMaxBody:
+		return fmt.Sprintf("%d bytes", length)
+	}
+	return ""
+}
+
+func omittedBody(reason string) string {
+	return "[body omitted: " + reason + "]"
+}
+
+// sensitiveFields are JSON object keys, compared case-insensitively, whose
+// values are masked in debug output. Keys containing any of
+// sensitiveFieldParts are masked too.
+var (
+	sensitiveFields     = []string{"api_key", "apikey", "authorization"}
+	sensitiveFieldParts = []string{"password", "token", "secret"}
+)
+
+// redactJSONBody masks sensitive fields in a JSON body. In request bodies
+// "code" is masked as well, since it carries one-time MFA codes. A body
+// that is not valid JSON is omitted, as it cannot be redacted.
+func redactJSONBody(data []byte, request bool) string {
+	if len(bytes.TrimSpace(data)) == 0 {
+		return ""
+	}
+	var v interface{}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return omittedBody("invalid JSON")
+	}
+	out, err := json.Marshal(redactJSONValue(v, request))
+	if err != nil {
+		return omittedBody(err.Error())
+	}
+	return string(out)
+}
+
+func redactJSONValue(v interface{}, request bool) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, field := range v {
+			if isSensitiveField(k, request) {
+				v[k] = redacted
+			} else {
+				v[k] = redactJSONValue(field, request)
+			}
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = redactJSONValue(item, request)
+		}
+	}
+	return v
+}
+
+func isSensitiveField(name string, request bool) bool {
+	name = strings.ToLower(name)
+	if request && name == "code" {
+		return true
+	}
+	for _, f := range sensitiveFields {
+		if name == f {
+			return true
+		}
+	}
+	for _, part := range sensitiveFieldParts {
+		if strings.Contains(name, part) {
+			return true
+		}
+	}
+	return false
+}
+
+// redactDump masks credential headers in the header block of a raw HTTP
+// dump, leaving the body untouched.
+func redactDump(dump []byte) []byte {
+	head, body, found := bytes.Cut(dump, []byte("\r\n\r\n"))
+	var out bytes.Buffer
+	sc := bufio.NewScanner(bytes.NewReader(head))
+	for first := true; sc.Scan(); first = false {
+		line := sc.Text()
+		if !first {
+			if name, _, ok := strings.Cut(line, ":"); ok && isRedactedHeader(name) {
+				line = name + ": " + redacted
+			}
+			out.WriteString("\r\n")
+		}
+		out.WriteString(line)
+	}
+	if found {
+		out.WriteString("\r\n\r\n")
+		out.Write(body)
+	}
+	return out.Bytes()
+}
+
+func isRedactedHeader(name string) bool {
+	for _, h := range redactedHeaders {
+		if strings.EqualFold(strings.TrimSpace(name), h) {
+			return true
+		}
+	}
+	return false
+}
+
+func redactHeader(h http.Header) {
+	for _, name := range redactedHeaders {
+		if h.Get(name) != "" {
+			h.Set(name, redacted)
+		}
+	}
+}
+
+// enableRestyDebug turns on resty's own request/response logging, routed
+// to the configured Logger with credentials masked.
+func enableRestyDebug(httpClient *resty.Client, logger func(format string, args ...interface{})) {
+	httpClient.
+		SetDebug(true).
+		SetLogger(restyLogger(logger)).
+		OnRequestLog(func(rl *resty.RequestLog) error {
+			redactHeader(rl.Header)
+			rl.Body = redactRestyBody(rl.Body, true)
+			return nil
+		}).
+		OnResponseLog(func(rl *resty.ResponseLog) error {
+			redactHeader(rl.Header)
+			rl.Body = redactRestyBody(rl.Body, false)
+			return nil
+		})
+}
+
+// redactRestyBody masks sensitive fields in a body resty is about to log.
+// resty's placeholders for bodies it does not show are kept.
+func redactRestyBody(body string, request bool) string {
+	trimmed := strings.TrimSpace(body)
+	switch {
+	case len(body) > debugMaxBody:
+		return omittedBody(fmt.Sprintf("%d bytes", len(body)))
+	case strings.HasPrefix(trimmed, "{"), strings.HasPrefix(trimmed, "["):
+		return redactJSONBody([]byte(body), request)
+	}
+	return body
+}
+
+// restyLogger adapts a printf-style logger to resty.Logger.
+type restyLogger func(format string, args ...interface{})
+
+func (l restyLogger) Errorf(format string, v ...interface{}) { l(format, v...) }
+func (l restyLogger) Warnf(format string, v ...interface{})  { l(format, v...) }
+func (l restyLogger) Debugf(format string, v ...interface{}) { l(format, v...) }
+
+// DebugLog returns the most recent raw HTTP exchanges, oldest first, with
+// credential headers redacted. It is nil unless the client was built with
+// Config.WithDebug.
+func (c *Client) DebugLog() []string {
+	if c.debug == nil {
+		return nil
+	}
+	return c.debug.snapshot()
+}
@@ -0,0 +1,181 @@
+package tavo
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestDebugLogRing(t *testing.T) {
+	l := newDebugLog(3)
+	if got := l.snapshot(); len(got) != 0 {
+		t.Fatalf("empty snapshot = %v", got)
+	}
+	for i := 1; i <= 5; i++ {
+		l.add(fmt.Sprint(i))
+	}
+	if got := strings.Join(l.snapshot(), ","); got != "3,4,5" {
+		t.Fatalf("snapshot = %s", got)
+	}
+}
+
+func TestDebugLogCapturesRedactedExchanges(t *testing.T) {
+	srv := httptest.NewServer(apiHandler(t, func(w http.ResponseWriter, r *http.Request) {
+		http.SetCookie(w, &http.Cookie{Name: "sid", Value: "secret-cookie"})
+		writeJSON(w, http.StatusOK, map[string]interface{}{"path": r.URL.Path})
+	}))
+	t.Cleanup(srv.Close)
+
+	var mu sync.Mutex
+	var logged strings.Builder
+	c := newTestClientFor(t, srv, func(cfg *Config) {
+		cfg.WithDebug(true).WithLogger(func(format string, args ...interface{}) {
+			mu.Lock()
+			fmt.Fprintf(&logged, format, args...)
+			mu.Unlock()
+		})
+		cfg.DebugLogSize = 2
+	})
+	ctx := context.Background()
+	for _, id := range []string{"s1", "s2", "s3"} {
+		if _, err := c.Scans().CreateScan(ctx, map[string]interface{}{"name": id}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	entries := c.DebugLog()
+	if len(entries) != 2 {
+		t.Fatalf("len(DebugLog) = %d, want 2", len(entries))
+	}
+	last := entries[1]
+	for _, want := range []string{"POST /api/v1/scans", `{"name":"s3"}`, "X-Api-Key: [REDACTED]", "Set-Cookie: [REDACTED]", `"path":"/scans"`} {
+		if !strings.Contains(last, want) {
+			t.Errorf("entry missing %q:\n%s", want, last)
+		}
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	for _, s := range append(entries, logged.String()) {
+		if strings.Contains(s, "test-key") || strings.Contains(s, "secret-cookie") {
+			t.Errorf("credential leaked:\n%s", s)
+		}
+	}
+	if !strings.Contains(logged.String(), "REQUEST") {
+		t.Error("resty debug output was not sent to the logger")
+	}
+}
+
+func TestDebugLogDisabled(t *testing.T) {
+	c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]interface{}{})
+	})
+	if _, err := c.Scans().GetScan(context.Background(), "s1"); err != nil {
+		t.Fatal(err)
+	}
+	if c.DebugLog() != nil {
+		t.Fatal("DebugLog should be nil when debug is off")
+	}
+}
+
+func TestDebugLogRedactsSensitiveFields(t *testing.T) {
+	srv := httptest.NewServer(apiHandler(t, func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"access_token": "tok-abc", "refresh_token": "ref-abc", "user": map[string]interface{}{"id": "u1", "api_key": "key-abc"},
+			"webhooks": []map[string]interface{}{{"id": "wh1", "secret": "whsec-abc"}},
+		})
+	}))
+	t.Cleanup(srv.Close)
+	c := newTestClientFor(t, srv, func(cfg *Config) { cfg.WithDebug(true) })
+	ctx := context.Background()
+
+	if err := c.Auth().ChangePassword(ctx, "old-pass-abc", "new-pass-abc"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Auth().LoginMFA(ctx, "chal-abc", "123456"); err != nil {
+		t.Fatal(err)
+	}
+
+	log := strings.Join(c.DebugLog(), "\n")
+	for _, secret := range []string{"old-pass-abc", "new-pass-abc", "chal-abc", "123456", "tok-abc", "ref-abc", "key-abc", "whsec-abc"} {
+		if strings.Contains(log, secret) {
+			t.Errorf("%q leaked:\n%s", secret, log)
+		}
+	}
+	for _, want := range []string{`"new_password":"[REDACTED]"`, `"code":"[REDACTED]"`, `"id":"wh1"`, `"id":"u1"`} {
+		if !strings.Contains(log, want) {
+			t.Errorf("log missing %q:\n%s", want, log)
+		}
+	}
+}
+
+func TestDebugLogOmitsLargeAndStreamedBodies(t *testing.T) {
+	release := make(chan struct{})
+	big := strings.Repeat("x", debugMaxBody)
+	srv := httptest.NewServer(apiHandler(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/large":
+			writeJSON(w, http.StatusOK, map[string]interface{}{"blob": big})
+		case "/events":
+			w.Header().Set("Content-Type", "text/event-stream")
+			io.WriteString(w, "data: first\n\n")
+			w.(http.Flusher).Flush()
+			<-release
+		}
+	}))
+	t.Cleanup(srv.Close)
+	t.Cleanup(func() { close(release) })
+	c := newTestClientFor(t, srv, func(cfg *Config) { cfg.WithDebug(true) })
+	ctx := context.Background()
+
+	resp, err := c.Do(ctx, http.MethodGet, "/large", nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp["blob"] != big {
+		t.Error("large body was not passed through intact")
+	}
+
+	// The stream must reach the caller while the server is still sending.
+	body, _, err := c.openStream(ctx, "/events", nil, "text/event-stream")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer body.Close()
+	line, err := bufio.NewReader(body).ReadString('\n')
+	if err != nil || line != "data: first\n" {
+		t.Fatalf("line = %q, err = %v", line, err)
+	}
+
+	entries := c.DebugLog()
+	if len(entries) != 2 {
+		t.Fatalf("len(DebugLog) = %d", len(entries))
+	}
+	if strings.Contains(entries[0], big) || !strings.Contains(entries[0], "[body omitted:") {
+		t.Errorf("large body not omitted:\n%.300s", entries[0])
+	}
+	if !strings.Contains(entries[1], "[body omitted: text/event-stream]") {
+		t.Errorf("stream body not omitted:\n%s", entries[1])
+	}
+}
+
+func TestDebugLogOmitsStreamedRequestBody(t *testing.T) {
+	srv := httptest.NewServer(apiHandler(t, func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]interface{}{})
+	}))
+	t.Cleanup(srv.Close)
+	c := newTestClientFor(t, srv, func(cfg *Config) { cfg.WithDebug(true) })
+
+	if _, err := c.Scans().CreateScanFromReader(context.Background(), io.MultiReader(strings.NewReader(`{"password":"p-abc"}`))); err != nil {
+		t.Fatal(err)
+	}
+	entry := c.DebugLog()[0]
+	if strings.Contains(entry, "p-abc") || !strings.Contains(entry, "[body omitted: streamed]") {
+		t.Errorf("entry:\n%s", entry)
+	}
+}
@@ -0,0 +1,17 @@
+// Package tavo is the Go client for the Tavo AI API.
+//
+// A Client is created from a Config and exposes the API through operation
+// groups such as Scans, Jobs, Reports and Users:
+//
+//	client, err := tavo.NewClient(tavo.NewConfig().WithAPIKey("your-api-key"))
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	scan, err := client.Scans().CreateScan(ctx, map[string]interface{}{
+//		"name":   "nightly",
+//		"target": "https://github.com/acme/app",
+//	})
+//
+// Every operation takes a context.Context and returns a *TavoError for
+// non-2xx API responses.
+package tavo
@@ -0,0 +1,133 @@
+package tavo
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// Sentinel errors matched by a TavoError's status code, so callers can
+// write errors.Is(err, tavo.ErrNotFound). Use errors.As to reach the
+// TavoError itself for the code, message and details.
+var (
+	// ErrNotFound matches 404 responses.
+	ErrNotFound = errors.New("tavo: not found")
+	// ErrUnauthorized matches 401 and 403 responses: missing or rejected
+	// credentials, or credentials lacking permission.
+	ErrUnauthorized = errors.New("tavo: unauthorized")
+	// ErrRateLimited matches 429 responses.
+	ErrRateLimited = errors.New("tavo: rate limited")
+	// ErrServer matches 5xx responses, including requests rejected by an
+	// open circuit breaker.
+	ErrServer = errors.New("tavo: server error")
+)
+
+// TavoError is returned for API responses outside the 2xx range.
+type TavoError struct {
+	StatusCode int                    `json:"-"`
+	Code       string                 `json:"code"`
+	Message    string                 `json:"message"`
+	Details    map[string]interface{} `json:"details,omitempty"`
+	// RequestID is the response's X-Request-ID, when the server sent one.
+	RequestID string `json:"-"`
+}
+
+// Error implements the error interface.
+func (e *TavoError) Error() string {
+	detail := fmt.Sprintf("status %d", e.StatusCode)
+	if e.Code != "" {
+		detail += ", code " + e.Code
+	}
+	if e.RequestID != "" {
+		detail += ", request ID " + e.RequestID
+	}
+	return fmt.Sprintf("tavo: %s (%s)", e.Message, detail)
+}
+
+// Unwrap returns the sentinel error for the status code, or nil when none
+// applies.
+func (e *TavoError) Unwrap() error {
+	switch {
+	case e.StatusCode == http.StatusNotFound:
+		return ErrNotFound
+	case e.StatusCode == http.StatusUnauthorized, e.StatusCode == http.StatusForbidden:
+		return ErrUnauthorized
+	case e.StatusCode == http.StatusTooManyRequests:
+		return ErrRateLimited
+	case e.StatusCode >= 500:
+		return ErrServer
+	}
+	return nil
+}
+
+// newTavoError builds a TavoError from an error response body. The API
+// reports errors either at the top level or nested under "error".
+func newTavoError(statusCode int, body []byte) *TavoError {
+	e := &TavoError{StatusCode: statusCode}
+	var envelope struct {
+		Error  json.RawMessage `json:"error"`
+		Detail string          `json:"detail"`
+		TavoError
+	}
+	if err := json.Unmarshal(body, &envelope); err == nil {
+		e.Code = envelope.Code
+		e.Message = envelope.Message
+		e.Details = envelope.Details
+		if len(envelope.Error) > 0 {
+			var nested TavoError
+			if json.Unmarshal(envelope.Error, &nested) == nil {
+				e.Code, e.Message, e.Details = nested.Code, nested.Message, nested.Details
+			} else {
+				var msg string
+				if json.Unmarshal(envelope.Error, &msg) == nil {
+					e.Message = msg
+				}
+			}
+		}
+		if e.Message == "" {
+			e.Message = envelope.Detail
+		}
+	}
+	if e.Message == "" {
+		e.Message = http.StatusText(statusCode)
+	}
+	return e
+}
+
+// FieldErrors returns the per-field validation messages in Details. Each
+// detail value may be a single message or a list of messages; values of
+// any other shape are ignored.
+func (e *TavoError) FieldErrors() map[string][]string {
+	fields := make(map[string][]string)
+	if e == nil {
+		return fields
+	}
+	for name, v := range e.Details {
+		switch v := v.(type) {
+		case string:
+			fields[name] = []string{v}
+		case []interface{}:
+			var msgs []string
+			for _, m := range v {
+				if s, ok := m.(string); ok {
+					msgs = append(msgs, s)
+				}
+			}
+			if len(msgs) > 0 {
+				fields[name] = msgs
+			}
+		case []string:
+			if len(v) > 0 {
+				fields[name] = v
+			}
+		}
+	}
+	return fields
+}
+
+// HasField reports whether Details carries a validation message for name.
+func (e *TavoError) HasField(name string) bool {
+	_, ok := e.FieldErrors()[name]
+	return ok
+}
@@ -0,0 +1,81 @@
+package tavo
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+func TestFieldErrors(t *testing.T) {
+	body := []byte(`{"error":{"code":"validation_error","message":"invalid input","details":{
+		"name":"is required",
+		"tags":["too many","must be lowercase"],
+		"limit":42,
+		"empty":[]
+	}}}`)
+	e := newTavoError(422, body)
+
+	want := map[string][]string{
+		"name": {"is required"},
+		"tags": {"too many", "must be lowercase"},
+	}
+	if got := e.FieldErrors(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("FieldErrors() = %v, want %v", got, want)
+	}
+	if !e.HasField("tags") || e.HasField("limit") || e.HasField("missing") {
+		t.Fatal("HasField mismatch")
+	}
+
+	var nilErr *TavoError
+	if got := nilErr.FieldErrors(); len(got) != 0 || nilErr.HasField("name") {
+		t.Fatalf("nil error FieldErrors() = %v", got)
+	}
+}
+
+func TestTavoErrorSentinels(t *testing.T) {
+	sentinels := []error{ErrNotFound, ErrUnauthorized, ErrRateLimited, ErrServer}
+	for _, tc := range []struct {
+		status int
+		want   error
+	}{
+		{http.StatusBadRequest, nil},
+		{http.StatusUnauthorized, ErrUnauthorized},
+		{http.StatusForbidden, ErrUnauthorized},
+		{http.StatusNotFound, ErrNotFound},
+		{http.StatusConflict, nil},
+		{http.StatusUnprocessableEntity, nil},
+		{http.StatusTooManyRequests, ErrRateLimited},
+		{http.StatusInternalServerError, ErrServer},
+		{http.StatusBadGateway, ErrServer},
+		{http.StatusServiceUnavailable, ErrServer},
+	} {
+		t.Run(strconv.Itoa(tc.status), func(t *testing.T) {
+			c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				writeJSON(w, tc.status, map[string]interface{}{"code": "x", "message": "boom"})
+			})
+			c.config.MaxRetries = 0
+			_, err := c.Scans().GetScan(context.Background(), "s1")
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			for _, s := range sentinels {
+				if got := errors.Is(err, s); got != (s == tc.want) {
+					t.Errorf("errors.Is(err, %v) = %v", s, got)
+				}
+			}
+			var te *TavoError
+			if !errors.As(err, &te) || te.StatusCode != tc.status || te.Message != "boom" {
+				t.Errorf("errors.As: %#v", te)
+			}
+		})
+	}
+}
+
+func TestCircuitOpenIsServerError(t *testing.T) {
+	if err := circuitOpenError(); !errors.Is(err, ErrServer) {
+		t.Fatalf("%v does not match ErrServer", err)
+	}
+}
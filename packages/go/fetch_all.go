@@ -0,0 +1,82 @@
+package tavo
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+)
+
+// fetchAllMaxPages stops FetchAll from looping forever on a server that
+// never reports the end of a list.
+const fetchAllMaxPages = 10000
+
+// FetchAll calls fetchPage with increasing offsets and returns every item.
+// It stops once it has total items or a page comes back empty. Because a
+// server reporting an inconsistent total could otherwise keep it going
+// forever, it also fails if a page starts with the same item as the
+// previous page (the offset is being ignored) or after fetchAllMaxPages
+// pages.
+func FetchAll(ctx context.Context, fetchPage func(offset int) (items []map[string]interface{}, total int, err error)) ([]map[string]interface{}, error) {
+	var (
+		all    []map[string]interface{}
+		prevID interface{}
+	)
+	for page := 0; ; page++ {
+		if err := ctx.Err(); err != nil {
+			return all, err
+		}
+		if page == fetchAllMaxPages {
+			return all, fmt.Errorf("tavo: pagination did not end after %d pages", fetchAllMaxPages)
+		}
+		items, total, err := fetchPage(len(all))
+		if err != nil {
+			return all, err
+		}
+		if len(items) == 0 {
+			return all, nil
+		}
+		// IDs may be any JSON value, including uncomparable objects and
+		// arrays, so they are compared with reflect.DeepEqual.
+		if id, ok := items[0]["id"]; ok && page > 0 && reflect.DeepEqual(id, prevID) {
+			return all, fmt.Errorf("tavo: pagination repeated item %v at offset %d", id, len(all))
+		}
+		prevID = items[0]["id"]
+		all = append(all, items...)
+		if len(all) >= total {
+			return all, nil
+		}
+	}
+}
+
+// listPages adapts a list method to FetchAll, paging with params' limit or
+// DefaultPageSize.
+func listPages(ctx context.Context, list func(context.Context, map[string]interface{}) (map[string]interface{}, error), params map[string]interface{}) func(int) ([]map[string]interface{}, int, error) {
+	return func(offset int) ([]map[string]interface{}, int, error) {
+		p := copyParams(params)
+		p["offset"] = offset
+		if _, ok := p["limit"]; !ok {
+			p["limit"] = DefaultPageSize
+		}
+		resp, err := list(ctx, p)
+		if err != nil {
+			return nil, 0, err
+		}
+		items, total := pageItems(resp, offset)
+		return items, total, nil
+	}
+}
+
+// ListAllScans returns every scan matching params, fetching all pages.
+func (s *ScanOperations) ListAllScans(ctx context.Context, params map[string]interface{}) ([]map[string]interface{}, error) {
+	return FetchAll(ctx, listPages(ctx, s.ListScans, params))
+}
+
+// ListAllJobs returns every job matching params, fetching all pages.
+func (j *JobOperations) ListAllJobs(ctx context.Context, params map[string]interface{}) ([]map[string]interface{}, error) {
+	return FetchAll(ctx, listPages(ctx, j.ListJobs, params))
+}
+
+// ListAllReports returns every report matching params, fetching all pages.
+func (r *ReportOperations) ListAllReports(ctx context.Context, params map[string]interface{}) ([]map[string]interface{}, error) {
+	return FetchAll(ctx, listPages(ctx, r.ListReports, params))
+}
@@ -0,0 +1,90 @@
+package tavo
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestListAllScans(t *testing.T) {
+	pages := 0
+	c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		pages++
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		if r.URL.Query().Get("status") != "completed" || r.URL.Query().Get("limit") != "2" {
+			t.Errorf("query = %v", r.URL.Query())
+		}
+		var items []map[string]interface{}
+		for i := offset; i < 5 && i < offset+2; i++ {
+			items = append(items, map[string]interface{}{"id": "s" + strconv.Itoa(i)})
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{"items": items, "total": 5})
+	})
+
+	scans, err := c.Scans().ListAllScans(context.Background(), map[string]interface{}{"status": "completed", "limit": 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(scans) != 5 || scans[4]["id"] != "s4" || pages != 3 {
+		t.Fatalf("scans = %v, pages = %d", scans, pages)
+	}
+}
+
+func TestFetchAllStopsOnEmptyPage(t *testing.T) {
+	// The server claims 100 items but only has 3.
+	all, err := FetchAll(context.Background(), func(offset int) ([]map[string]interface{}, int, error) {
+		if offset >= 3 {
+			return nil, 100, nil
+		}
+		return []map[string]interface{}{{"id": offset}}, 100, nil
+	})
+	if err != nil || len(all) != 3 {
+		t.Fatalf("all = %v, err = %v", all, err)
+	}
+}
+
+func TestFetchAllDetectsIgnoredOffset(t *testing.T) {
+	calls := 0
+	_, err := FetchAll(context.Background(), func(offset int) ([]map[string]interface{}, int, error) {
+		calls++
+		return []map[string]interface{}{{"id": "same"}}, 1000, nil
+	})
+	if err == nil || !strings.Contains(err.Error(), "repeated") || calls != 2 {
+		t.Fatalf("err = %v, calls = %d", err, calls)
+	}
+}
+
+func TestFetchAllCompoundIDs(t *testing.T) {
+	_, err := FetchAll(context.Background(), func(offset int) ([]map[string]interface{}, int, error) {
+		return []map[string]interface{}{{"id": map[string]interface{}{"org": "o1", "n": []interface{}{1.0}}}}, 1000, nil
+	})
+	if err == nil || !strings.Contains(err.Error(), "repeated") {
+		t.Fatalf("err = %v", err)
+	}
+
+	all, err := FetchAll(context.Background(), func(offset int) ([]map[string]interface{}, int, error) {
+		return []map[string]interface{}{{"id": []interface{}{"scan", float64(offset)}}}, 3, nil
+	})
+	if err != nil || len(all) != 3 {
+		t.Fatalf("all = %v, err = %v", all, err)
+	}
+}
+
+func TestListAllJobsAndReports(t *testing.T) {
+	c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"items": []map[string]interface{}{{"id": strings.TrimPrefix(r.URL.Path, "/")}},
+			"total": 1,
+		})
+	})
+	jobs, err := c.Jobs().ListAllJobs(context.Background(), nil)
+	if err != nil || len(jobs) != 1 || jobs[0]["id"] != "jobs" {
+		t.Fatalf("jobs = %v, err = %v", jobs, err)
+	}
+	reports, err := c.Reports().ListAllReports(context.Background(), nil)
+	if err != nil || len(reports) != 1 || reports[0]["id"] != "reports" {
+		t.Fatalf("reports = %v, err = %v", reports, err)
+	}
+}
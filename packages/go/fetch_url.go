@@ -0,0 +1,93 @@
+package tavo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// FetchURL downloads rawURL, such as a presigned report link received in a
+// webhook, to w using the client's transport, so proxy and TLS settings
+// apply. Tavo credentials and default headers are only sent when the URL
+// is on the BaseURL host, and are dropped if a redirect leaves that host,
+// so an API key never reaches external storage.
+func (c *Client) FetchURL(ctx context.Context, rawURL string, w io.Writer) error {
+	u, err := url.Parse(rawURL)
+	if err != nil || !u.IsAbs() || u.Host == "" {
+		return fmt.Errorf("tavo: fetch URL %q must be absolute", rawURL)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return err
+	}
+	if c.isAPIHost(u) {
+		if err := c.setAPIHeaders(req.Header); err != nil {
+			return err
+		}
+	}
+	req.Header.Set("User-Agent", userAgent(c.config))
+
+	hc := *c.http.GetClient()
+	hc.CheckRedirect = func(r *http.Request, via []*http.Request) error {
+		if len(via) >= 10 {
+			return errors.New("stopped after 10 redirects")
+		}
+		if !c.isAPIHost(r.URL) {
+			// Keep only what a request to a foreign host starts with.
+			r.Header = http.Header{"User-Agent": r.Header.Values("User-Agent")}
+		}
+		return nil
+	}
+
+	resp, err := hc.Do(req)
+	if err != nil {
+		return fmt.Errorf("tavo: fetching %s: %w", u.Redacted(), err)
+	}
+	if err := decodeContentEncoding(resp); err != nil {
+		return fmt.Errorf("tavo: fetching %s: %w", u.Redacted(), err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+		return responseError(resp.StatusCode, resp.Header, body)
+	}
+	if _, err := io.Copy(w, resp.Body); err != nil {
+		return fmt.Errorf("tavo: reading %s: %w", u.Redacted(), err)
+	}
+	return nil
+}
+
+// isAPIHost reports whether u has the scheme and host of BaseURL.
+func (c *Client) isAPIHost(u *url.URL) bool {
+	base, err := url.Parse(c.config.BaseURL)
+	if err != nil {
+		return false
+	}
+	return strings.EqualFold(u.Scheme, base.Scheme) && strings.EqualFold(u.Host, base.Host)
+}
+
+// setAPIHeaders copies the headers every API request carries, credentials
+// included, into h.
+func (c *Client) setAPIHeaders(h http.Header) error {
+	for k, v := range c.http.Header {
+		h[k] = append([]string(nil), v...)
+	}
+	if c.http.Token != "" {
+		h.Set("Authorization", "Bearer "+c.http.Token)
+	}
+	r := c.http.R()
+	if err := c.applyCredentials(r); err != nil {
+		return err
+	}
+	if r.Token != "" {
+		h.Set("Authorization", "Bearer "+r.Token)
+	}
+	for k, v := range r.Header {
+		h[k] = v
+	}
+	return nil
+}
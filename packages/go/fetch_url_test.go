@@ -0,0 +1,61 @@
+package tavo
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestFetchURLStripsAuthForForeignHosts(t *testing.T) {
+	storage := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for _, h := range []string{"X-API-Key", "Authorization", "X-Organization-ID", "X-Trace"} {
+			if v := r.Header.Get(h); v != "" {
+				t.Errorf("storage host received %s: %q", h, v)
+			}
+		}
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		_, _ = w.Write([]byte("report-bytes"))
+	}))
+	defer storage.Close()
+
+	var apiKey string
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		apiKey = r.Header.Get("X-API-Key")
+		http.Redirect(w, r, storage.URL+"/reports/r1.pdf?sig=abc", http.StatusFound)
+	}))
+	defer api.Close()
+
+	c := newTestClientFor(t, api, func(cfg *Config) {
+		cfg.WithOrganization("org1").WithDefaultHeaders(map[string]string{"X-Trace": "t"})
+	})
+	ctx := context.Background()
+
+	var buf bytes.Buffer
+	if err := c.FetchURL(ctx, storage.URL+"/reports/r1.pdf?sig=abc", &buf); err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != "report-bytes" {
+		t.Fatalf("body = %q", buf.String())
+	}
+
+	buf.Reset()
+	if err := c.FetchURL(ctx, api.URL+"/api/v1/reports/r1/download", &buf); err != nil {
+		t.Fatal(err)
+	}
+	if apiKey != "test-key" || buf.String() != "report-bytes" {
+		t.Fatalf("API host saw key %q; body = %q", apiKey, buf.String())
+	}
+
+	if err := c.FetchURL(ctx, storage.URL+"/missing", &buf); !errors.Is(err, ErrUnauthorized) {
+		t.Fatalf("err = %v", err)
+	}
+	if err := c.FetchURL(ctx, "/relative", &buf); err == nil {
+		t.Fatal("relative URL accepted")
+	}
+}
@@ -0,0 +1,83 @@
+package tavo
+
+import (
+	"context"
+	"fmt"
+	"sort"
+)
+
+// findingLess compares findings by the named key, ascending.
+var findingLess = map[string]func(a, b Finding) bool{
+	"severity": func(a, b Finding) bool { return a.SeverityLevel() < b.SeverityLevel() },
+	"rule_id":  func(a, b Finding) bool { return a.RuleID < b.RuleID },
+	"file":     func(a, b Finding) bool { return a.File < b.File },
+	"line":     func(a, b Finding) bool { return a.Line < b.Line },
+}
+
+// sortFindings sorts findings by key in order ("asc" or "desc"), breaking
+// ties by ascending rule ID and then by their original order.
+func sortFindings(findings []Finding, key, order string) {
+	less := findingLess[key]
+	desc := order == "desc"
+	sort.SliceStable(findings, func(i, j int) bool {
+		a, b := findings[i], findings[j]
+		if desc {
+			a, b = b, a
+		}
+		if less(a, b) {
+			return true
+		}
+		if less(b, a) {
+			return false
+		}
+		return findings[i].RuleID < findings[j].RuleID
+	})
+}
+
+// checkSort reports whether key and order can be used with sortFindings.
+func checkSort(key, order string) error {
+	if _, ok := findingLess[key]; !ok {
+		return fmt.Errorf("tavo: cannot sort findings by %q (want severity, rule_id, file or line)", key)
+	}
+	if order != "" && order != "asc" && order != "desc" {
+		return fmt.Errorf("tavo: sort order %q must be asc or desc", order)
+	}
+	return nil
+}
+
+// newSortedFindingIterator streams pages while the server sorts them and
+// otherwise collects every page and sorts locally. Whether the server
+// sorts is decided by the first page.
+func newSortedFindingIterator(ctx context.Context, key, order string, fetch func(ctx context.Context, offset int) ([]Finding, int, bool, error)) *Iterator[Finding] {
+	if err := checkSort(key, order); err != nil {
+		return &Iterator[Finding]{ctx: ctx, err: err}
+	}
+	offset := 0
+	first, serverSorted := true, false
+	return &Iterator[Finding]{ctx: ctx, next: func(ctx context.Context) ([]Finding, bool, error) {
+		items, total, sorted, err := fetch(ctx, offset)
+		if err != nil {
+			return nil, false, err
+		}
+		offset += len(items)
+		more := len(items) > 0 && offset < total
+		if first {
+			first, serverSorted = false, sorted
+		}
+		if serverSorted {
+			return items, more, nil
+		}
+		all := items
+		for more {
+			page, total, _, err := fetch(ctx, offset)
+			if err != nil {
+				return nil, false, err
+			}
+			all = append(all, page...)
+			offset += len(page)
+			more = len(page) > 0 && offset < total
+		}
+		sortFindings(all, key, order)
+		return all, false, nil
+	}}
+}
@@ -0,0 +1,105 @@
+package tavo
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"testing"
+)
+
+// findingsServer serves findings in the given order, paginated, echoing
+// the sort key when echoSort is set.
+func findingsServer(t *testing.T, findings []map[string]interface{}, echoSort bool, seen *[]string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		*seen = append(*seen, q.Get("sort")+" "+q.Get("order")+" "+q.Get("offset"))
+		offset, _ := strconv.Atoi(q.Get("offset"))
+		limit, _ := strconv.Atoi(q.Get("limit"))
+		end := offset + limit
+		if end > len(findings) {
+			end = len(findings)
+		}
+		resp := map[string]interface{}{"items": findings[offset:end], "total": len(findings)}
+		if echoSort {
+			resp["sort"] = q.Get("sort")
+		}
+		writeJSON(w, http.StatusOK, resp)
+	}
+}
+
+func collectRuleIDs(t *testing.T, it *Iterator[Finding]) []string {
+	t.Helper()
+	var ids []string
+	for it.Next() {
+		ids = append(ids, it.Item().RuleID)
+	}
+	if err := it.Err(); err != nil {
+		t.Fatal(err)
+	}
+	return ids
+}
+
+func TestIterateFindingsSortsLocallyWhenServerDoesNot(t *testing.T) {
+	findings := []map[string]interface{}{
+		{"rule_id": "r-low", "severity": "low"},
+		{"rule_id": "r-crit-b", "severity": "critical"},
+		{"rule_id": "r-med", "severity": "medium"},
+		{"rule_id": "r-crit-a", "severity": "critical"},
+		{"rule_id": "r-high", "severity": "high"},
+	}
+	var seen []string
+	c, _ := newTestClient(t, findingsServer(t, findings, false, &seen))
+
+	it := c.Scans().IterateFindings(context.Background(), "s1", ResultFilter{SortBy: "severity", Order: "desc", Limit: 2})
+	got := collectRuleIDs(t, it)
+	want := []string{"r-crit-a", "r-crit-b", "r-high", "r-med", "r-low"}
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("got %v, want %v", got, want)
+		}
+	}
+	if len(seen) != 3 || seen[0] != "severity desc " {
+		t.Fatalf("requests = %q", seen)
+	}
+}
+
+func TestIterateFindingsTrustsServerSort(t *testing.T) {
+	// Deliberately not in severity order: a server echoing the sort key is
+	// trusted, so the iterator must pass pages through unchanged.
+	findings := []map[string]interface{}{
+		{"rule_id": "a", "severity": "low"},
+		{"rule_id": "b", "severity": "critical"},
+		{"rule_id": "c", "severity": "high"},
+	}
+	var seen []string
+	c, _ := newTestClient(t, findingsServer(t, findings, true, &seen))
+
+	it := c.Scans().IterateFindings(context.Background(), "s1", ResultFilter{SortBy: "severity", Limit: 2})
+	got := collectRuleIDs(t, it)
+	if len(got) != 3 || got[0] != "a" || got[1] != "b" || got[2] != "c" {
+		t.Fatalf("got %v", got)
+	}
+}
+
+func TestIterateFindingsRejectsBadSort(t *testing.T) {
+	c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Error("request sent for an invalid sort")
+	})
+	for _, f := range []ResultFilter{{SortBy: "colour"}, {SortBy: "line", Order: "down"}} {
+		it := c.Scans().IterateFindings(context.Background(), "s1", f)
+		if it.Next() || it.Err() == nil {
+			t.Fatalf("%+v: expected an error", f)
+		}
+	}
+}
+
+func TestSortFindingsAscending(t *testing.T) {
+	f := []Finding{{RuleID: "z", Line: 3}, {RuleID: "b", Line: 1}, {RuleID: "a", Line: 3}}
+	sortFindings(f, "line", "")
+	if f[0].RuleID != "b" || f[1].RuleID != "a" || f[2].RuleID != "z" {
+		t.Fatalf("sorted = %+v", f)
+	}
+}
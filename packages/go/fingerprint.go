@@ -0,0 +1,74 @@
+package tavo
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"path"
+	"strings"
+)
+
+// Fingerprint returns a stable identifier for the finding that survives
+// unrelated edits moving it to another line. It hashes the rule ID, the
+// file path and the flagged source line (see FingerprintOf), taken from
+// the snippet if it covers the finding's line and from Code otherwise. When
+// the finding has neither, the message stands in for the code.
+func (f Finding) Fingerprint() string {
+	code := f.Code
+	if code == "" {
+		code = f.Message
+	}
+	if s := f.Snippet; s != nil && f.Line >= s.StartLine && f.Line <= s.EndLine() {
+		code = s.Lines[f.Line-s.StartLine]
+	}
+	return FingerprintOf(f.RuleID, f.File, code)
+}
+
+// FingerprintOf computes a finding fingerprint. It is the hex SHA-256 of
+//
+//	"v1" NUL ruleID NUL normalizedPath NUL normalizedCode
+//
+// where normalizedPath uses forward slashes with no leading "./" and
+// normalizedCode is code with leading and trailing whitespace removed and
+// inner whitespace runs collapsed to one space.
+func FingerprintOf(ruleID, file, code string) string {
+	h := sha256.New()
+	for _, part := range []string{"v1", ruleID, normalizeFingerprintPath(file), strings.Join(strings.Fields(code), " ")} {
+		h.Write([]byte(part))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func normalizeFingerprintPath(p string) string {
+	p = strings.ReplaceAll(p, "\\", "/")
+	if p == "" {
+		return ""
+	}
+	return strings.TrimPrefix(path.Clean(p), "./")
+}
+
+// FingerprintMap computes the fingerprint of a finding in its raw map form,
+// as returned by GetScanResults. It is the Fingerprint of the decoded
+// Finding, so both forms of a finding always agree.
+func FingerprintMap(m map[string]interface{}) string {
+	var f Finding
+	_ = decodeMap(m, &f) // fields of the wrong type are simply left empty
+	return f.Fingerprint()
+}
+
+// DedupeResults collapses findings with the same fingerprint (see
+// FingerprintMap), keeping the first occurrence of each and preserving
+// order. It runs locally; no request is made.
+func (s *ScanOperations) DedupeResults(findings []map[string]interface{}) []map[string]interface{} {
+	seen := make(map[string]bool, len(findings))
+	out := make([]map[string]interface{}, 0, len(findings))
+	for _, f := range findings {
+		fp := FingerprintMap(f)
+		if seen[fp] {
+			continue
+		}
+		seen[fp] = true
+		out = append(out, f)
+	}
+	return out
+}
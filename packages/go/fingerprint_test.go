@@ -0,0 +1,107 @@
+package tavo
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFingerprintIgnoresLineShifts(t *testing.T) {
+	before := Finding{
+		RuleID: "R1", File: "./src/app.go", Line: 10, Message: "hardcoded secret",
+		Snippet: &Snippet{StartLine: 9, Lines: []string{"func f() {", "\tkey := \"abc\"", "}"}},
+	}
+	after := Finding{
+		RuleID: "R1", File: "src/app.go", Line: 25, Message: "hardcoded secret",
+		Snippet: &Snippet{StartLine: 24, Lines: []string{"// moved", "    key   := \"abc\"  ", "}"}},
+	}
+	if before.Fingerprint() != after.Fingerprint() {
+		t.Fatal("fingerprint changed when the finding moved")
+	}
+
+	other := after
+	other.Snippet = &Snippet{StartLine: 25, Lines: []string{"key := \"xyz\""}}
+	if other.Fingerprint() == after.Fingerprint() {
+		t.Fatal("different code produced the same fingerprint")
+	}
+	if other.Fingerprint() == FingerprintOf("R2", "src/app.go", "key := \"xyz\"") {
+		t.Fatal("rule ID not part of fingerprint")
+	}
+}
+
+func TestFingerprintWithoutSnippetUsesMessage(t *testing.T) {
+	f := Finding{RuleID: "R1", File: "a.go", Line: 3, Message: "weak hash"}
+	if got, want := f.Fingerprint(), FingerprintOf("R1", "a.go", "weak hash"); got != want {
+		t.Fatalf("Fingerprint() = %s, want %s", got, want)
+	}
+	if len(f.Fingerprint()) != 64 {
+		t.Fatalf("fingerprint %q is not hex SHA-256", f.Fingerprint())
+	}
+}
+
+func TestDiffFindingsByFingerprint(t *testing.T) {
+	snippet := func(line int, code string) *Snippet { return &Snippet{StartLine: line, Lines: []string{code}} }
+	baseline := []Finding{
+		{RuleID: "R1", File: "a.go", Line: 5, Snippet: snippet(5, "x := md5.New()")},
+	}
+	current := []Finding{
+		{RuleID: "R1", File: "a.go", Line: 9, Snippet: snippet(9, "x := md5.New()")},
+		{RuleID: "R1", File: "a.go", Line: 12, Snippet: snippet(12, "y := md5.New()")},
+	}
+	diff := diffFindings(baseline, current, compareOptions{byFingerprint: true})
+	if len(diff.Unchanged) != 1 || diff.Unchanged[0].Line != 9 || len(diff.Added) != 1 || len(diff.Removed) != 0 {
+		t.Fatalf("diff = %+v", diff)
+	}
+}
+
+func TestDedupeResults(t *testing.T) {
+	findings := []map[string]interface{}{
+		{"id": "1", "rule_id": "R1", "file": "./src/app.go", "line": 10, "code": "key := \"abc\""},
+		{"id": "2", "rule_id": "R1", "file": "src/app.go", "line": 42, "code": "  key   :=  \"abc\" "},
+		{"id": "3", "rule_id": "R1", "file": "src/app.go", "line": 50, "code": "key := \"xyz\""},
+		{"id": "4", "rule_id": "R2", "file": "src/app.go", "line": 10, "code": "key := \"abc\""},
+		{"id": "5", "rule_id": "R1", "file": "src\\app.go", "line": 11, "code": "key := \"abc\""},
+		{"id": "6", "rule_id": "R3", "file": "a.go", "line": 2, "message": "m",
+			"snippet": map[string]interface{}{"start_line": 1, "lines": []interface{}{"x", "y := 1"}}},
+		{"id": "7", "rule_id": "R3", "file": "a.go", "line": 8, "message": "m",
+			"snippet": map[string]interface{}{"start_line": 7, "lines": []interface{}{"z", "y  :=  1"}}},
+	}
+
+	var ids []string
+	for _, f := range (&ScanOperations{}).DedupeResults(findings) {
+		ids = append(ids, f["id"].(string))
+	}
+	if got := strings.Join(ids, ","); got != "1,3,4,6" {
+		t.Fatalf("kept %s, want 1,3,4,6", got)
+	}
+
+	if FingerprintMap(findings[0]) != FingerprintOf("R1", "src/app.go", `key := "abc"`) {
+		t.Fatal("FingerprintMap disagrees with FingerprintOf")
+	}
+	typed := Finding{RuleID: "R3", File: "a.go", Line: 2, Message: "m", Snippet: &Snippet{StartLine: 1, Lines: []string{"x", "y := 1"}}}
+	if FingerprintMap(findings[5]) != typed.Fingerprint() {
+		t.Fatal("FingerprintMap disagrees with Finding.Fingerprint")
+	}
+}
+
+func TestFingerprintMapMatchesDecodedFinding(t *testing.T) {
+	findings := []map[string]interface{}{
+		{"rule_id": "R1", "file": "a.go", "line": 3, "message": "weak hash"},
+		{"rule_id": "R1", "file": "a.go", "line": 3, "message": "weak hash", "code": "md5.New()"},
+		{"rule_id": "R1", "file": "a.go", "line": 3, "code": "md5.New()",
+			"snippet": map[string]interface{}{"start_line": 3, "lines": []interface{}{"sha1.New()"}}},
+		{"rule_id": "R1", "file": "a.go", "line": 9, "message": "m", "code": "md5.New()",
+			"snippet": map[string]interface{}{"start_line": 3, "lines": []interface{}{"sha1.New()"}}},
+	}
+	for i, m := range findings {
+		var f Finding
+		if err := decodeMap(m, &f); err != nil {
+			t.Fatal(err)
+		}
+		if FingerprintMap(m) != f.Fingerprint() {
+			t.Errorf("finding %d: FingerprintMap disagrees with Finding.Fingerprint", i)
+		}
+	}
+	if FingerprintMap(findings[0]) == FingerprintMap(findings[1]) {
+		t.Error("code not part of the fingerprint")
+	}
+}
@@ -0,0 +1,14 @@
+module github.com/TavoAI/tavo-go-sdk
+
+go 1.21
+
+require (
+	github.com/go-resty/resty/v2 v2.16.5
+	github.com/robfig/cron/v3 v3.0.1
+	golang.org/x/time v0.6.0
+	gopkg.in/yaml.v3 v3.0.1
+)
+
+require (
+	golang.org/x/net v0.33.0 // indirect
+)
@@ -0,0 +1,117 @@
+package tavo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// HealthStatus is the body of the readiness and liveness endpoints.
+type HealthStatus struct {
+	Status string            `json:"status"`
+	Checks map[string]string `json:"checks,omitempty"`
+}
+
+// HealthCheck reports the combined API health.
+func (c *Client) HealthCheck(ctx context.Context) (map[string]interface{}, error) {
+	return c.makeRequest(ctx, http.MethodGet, "/health", nil, nil)
+}
+
+// Readiness reports whether the API is ready to serve traffic.
+//
+// A non-2xx response still returns the decoded HealthStatus alongside a
+// *TavoError, so callers can see which checks failed. Probes are never
+// retried.
+func (c *Client) Readiness(ctx context.Context) (*HealthStatus, error) {
+	return c.healthProbe(ctx, "/health/ready")
+}
+
+// Liveness reports whether the API process is alive. It behaves like
+// Readiness for non-2xx responses.
+func (c *Client) Liveness(ctx context.Context) (*HealthStatus, error) {
+	return c.healthProbe(ctx, "/health/live")
+}
+
+func (c *Client) healthProbe(ctx context.Context, path string) (*HealthStatus, error) {
+	resp, err := c.execute(ctx, &apiRequest{method: http.MethodGet, path: path, noRetry: true})
+	if err != nil {
+		return nil, err
+	}
+
+	status := &HealthStatus{}
+	body, decodeErr := decodeObject(resp.Body())
+	if decodeErr == nil {
+		decodeErr = decodeMap(body, status)
+	}
+	if code := resp.StatusCode(); code < 200 || code > 299 {
+		return status, responseError(code, resp.Header(), resp.Body())
+	}
+	if decodeErr != nil {
+		return nil, decodeErr
+	}
+	return status, nil
+}
+
+// ServerInfo describes the API server's build.
+type ServerInfo struct {
+	Version  string `json:"version"`
+	BuildSHA string `json:"build_sha"`
+	// APIVersions lists the API versions the server accepts, such as "v1".
+	APIVersions []string `json:"api_versions"`
+}
+
+// Supports reports whether the server accepts apiVersion.
+func (s *ServerInfo) Supports(apiVersion string) bool {
+	for _, v := range s.APIVersions {
+		if v == apiVersion {
+			return true
+		}
+	}
+	return false
+}
+
+// ErrIncompatibleServer is returned by CheckCompatibility when the server
+// does not support the API version the client is configured for.
+var ErrIncompatibleServer = errors.New("tavo: server does not support the configured API version")
+
+// ServerInfo fetches the server's version and supported API versions. The
+// endpoint lives under the configured API version, so a server that does not
+// serve that version answers it with 404 Not Found.
+func (c *Client) ServerInfo(ctx context.Context) (*ServerInfo, error) {
+	resp, err := c.makeRequest(ctx, http.MethodGet, "/version", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	var info ServerInfo
+	if err := decodeMap(resp, &info); err != nil {
+		return nil, err
+	}
+	return &info, nil
+}
+
+// CheckCompatibility verifies that the server supports the client's API
+// version (Config.APIVersion). A mismatch, including a 404 from the
+// versioned /version endpoint, is logged as a warning and returned as an
+// error wrapping ErrIncompatibleServer; call it at startup to catch version
+// drift during upgrades.
+func (c *Client) CheckCompatibility(ctx context.Context) error {
+	info, err := c.ServerInfo(ctx)
+	var tErr *TavoError
+	if errors.As(err, &tErr) && tErr.StatusCode == http.StatusNotFound {
+		err = fmt.Errorf("%w: client uses %q (SDK %s), server has no %s API: %v",
+			ErrIncompatibleServer, c.config.APIVersion, Version, c.config.APIVersion, err)
+		c.logf("tavo: warning: %v", err)
+		return err
+	}
+	if err != nil {
+		return err
+	}
+	if info.Supports(c.config.APIVersion) {
+		return nil
+	}
+	err = fmt.Errorf("%w: client uses %q (SDK %s), server %s supports %v",
+		ErrIncompatibleServer, c.config.APIVersion, Version, info.Version, info.APIVersions)
+	c.logf("tavo: warning: %v", err)
+	return err
+}
@@ -0,0 +1,118 @@
+package tavo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestReadinessAndLiveness(t *testing.T) {
+	c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/health/live":
+			writeJSON(w, http.StatusOK, map[string]interface{}{"status": "ok"})
+		case "/health/ready":
+			writeJSON(w, http.StatusOK, map[string]interface{}{
+				"status": "ok",
+				"checks": map[string]string{"database": "ok", "queue": "ok"},
+			})
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	})
+	ctx := context.Background()
+
+	live, err := c.Liveness(ctx)
+	if err != nil || live.Status != "ok" {
+		t.Fatalf("live = %+v, err = %v", live, err)
+	}
+	ready, err := c.Readiness(ctx)
+	if err != nil || ready.Checks["database"] != "ok" {
+		t.Fatalf("ready = %+v, err = %v", ready, err)
+	}
+}
+
+func TestReadinessParsesUnhealthyResponse(t *testing.T) {
+	var calls int32
+	c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		writeJSON(w, http.StatusServiceUnavailable, map[string]interface{}{
+			"status": "degraded",
+			"checks": map[string]string{"database": "unreachable"},
+		})
+	})
+
+	status, err := c.Readiness(context.Background())
+	var tErr *TavoError
+	if !errors.As(err, &tErr) || tErr.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("err = %v", err)
+	}
+	if status == nil || status.Status != "degraded" || status.Checks["database"] != "unreachable" {
+		t.Fatalf("status = %+v", status)
+	}
+	if calls != 1 {
+		t.Fatalf("probe was retried: %d calls", calls)
+	}
+}
+
+func TestServerInfoAndCompatibility(t *testing.T) {
+	versions := []string{"v1", "v2"}
+	c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/version" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{"version": "2.4.1", "build_sha": "abc123", "api_versions": versions})
+	})
+	var warnings []string
+	c.config.Logger = func(format string, args ...interface{}) { warnings = append(warnings, fmt.Sprintf(format, args...)) }
+	ctx := context.Background()
+
+	info, err := c.ServerInfo(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Version != "2.4.1" || info.BuildSHA != "abc123" || !info.Supports("v2") {
+		t.Fatalf("info = %+v", info)
+	}
+	if err := c.CheckCompatibility(ctx); err != nil || len(warnings) != 0 {
+		t.Fatalf("err = %v, warnings = %v", err, warnings)
+	}
+
+	versions = []string{"v2"}
+	err = c.CheckCompatibility(ctx)
+	if !errors.Is(err, ErrIncompatibleServer) || !strings.Contains(err.Error(), `"v1"`) {
+		t.Fatalf("err = %v", err)
+	}
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "warning") {
+		t.Fatalf("warnings = %v", warnings)
+	}
+}
+
+func TestCheckCompatibilityUnservedVersion(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/version" {
+			writeJSON(w, http.StatusNotFound, map[string]interface{}{"detail": "Not Found"})
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{"version": "1.9.0", "api_versions": []string{"v1"}})
+	}))
+	defer srv.Close()
+	var warnings []string
+	c := newTestClientFor(t, srv, func(cfg *Config) {
+		cfg.WithAPIVersion("v2")
+		cfg.Logger = func(format string, args ...interface{}) { warnings = append(warnings, fmt.Sprintf(format, args...)) }
+	})
+
+	err := c.CheckCompatibility(context.Background())
+	if !errors.Is(err, ErrIncompatibleServer) || !strings.Contains(err.Error(), `"v2"`) {
+		t.Fatalf("err = %v", err)
+	}
+	if len(warnings) != 1 {
+		t.Fatalf("warnings = %v", warnings)
+	}
+}
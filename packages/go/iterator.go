@@ -0,0 +1,143 @@
+package tavo
+
+import (
+	"context"
+	"fmt"
+)
+
+// DefaultPageSize is the page size iterators request when none is given.
+const DefaultPageSize = 50
+
+// pageFetcher loads the page starting at offset and reports the total
+// number of items the server holds.
+type pageFetcher[T any] func(ctx context.Context, offset int) (items []T, total int, err error)
+
+// cursorFetcher loads the page at cursor, "" being the first, and returns
+// the cursor of the next page, "" after the last.
+type cursorFetcher[T any] func(ctx context.Context, cursor string) (items []T, next string, err error)
+
+// pageSource loads the next page and reports whether more may follow.
+type pageSource[T any] func(ctx context.Context) (items []T, more bool, err error)
+
+// Iterator walks a paginated list endpoint one item at a time, fetching
+// pages lazily. Offset and cursor pagination are both supported:
+//
+//	it := client.Scans().IterateScans(ctx, nil)
+//	for it.Next() {
+//		scan := it.Item()
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+type Iterator[T any] struct {
+	ctx  context.Context
+	next pageSource[T]
+	buf  []T
+	cur  T
+	done bool
+	err  error
+}
+
+// newIterator iterates an offset-paginated endpoint until a page is empty
+// or the reported total is reached.
+func newIterator[T any](ctx context.Context, fetch pageFetcher[T]) *Iterator[T] {
+	offset := 0
+	return &Iterator[T]{ctx: ctx, next: func(ctx context.Context) ([]T, bool, error) {
+		items, total, err := fetch(ctx, offset)
+		if err != nil {
+			return nil, false, err
+		}
+		offset += len(items)
+		return items, offset < total, nil
+	}}
+}
+
+// newCursorIterator iterates a cursor-paginated endpoint until the server
+// returns no next cursor. A server repeating a cursor is an error rather
+// than an endless loop.
+func newCursorIterator[T any](ctx context.Context, fetch cursorFetcher[T]) *Iterator[T] {
+	cursor := ""
+	return &Iterator[T]{ctx: ctx, next: func(ctx context.Context) ([]T, bool, error) {
+		items, next, err := fetch(ctx, cursor)
+		if err != nil {
+			return nil, false, err
+		}
+		if next != "" && next == cursor {
+			return nil, false, fmt.Errorf("tavo: server repeated page cursor %q", cursor)
+		}
+		cursor = next
+		return items, next != "", nil
+	}}
+}
+
+// Next advances to the next item and reports whether there is one.
+func (it *Iterator[T]) Next() bool {
+	if it.err != nil {
+		return false
+	}
+	if len(it.buf) == 0 {
+		if it.done {
+			return false
+		}
+		items, more, err := it.next(it.ctx)
+		if err != nil {
+			it.err = err
+			return false
+		}
+		it.done = !more
+		if len(items) == 0 {
+			it.done = true
+			return false
+		}
+		it.buf = items
+	}
+	it.cur = it.buf[0]
+	it.buf = it.buf[1:]
+	return true
+}
+
+// Item returns the current item.
+func (it *Iterator[T]) Item() T { return it.cur }
+
+// Err returns the first error encountered while fetching pages.
+func (it *Iterator[T]) Err() error { return it.err }
+
+// pageItems extracts the item list and total from a list response of the
+// form {"items": [...], "total": n}. When total is absent it is the number
+// of items seen so far, which ends iteration on a short page.
+func pageItems(resp map[string]interface{}, offset int) ([]map[string]interface{}, int) {
+	raw, _ := resp["items"].([]interface{})
+	items := make([]map[string]interface{}, 0, len(raw))
+	for _, v := range raw {
+		if m, ok := v.(map[string]interface{}); ok {
+			items = append(items, m)
+		}
+	}
+	total, ok := toInt(resp["total"])
+	if !ok {
+		total = offset + len(items)
+	}
+	return items, total
+}
+
+// copyParams returns a shallow copy of params so callers' maps are never
+// mutated.
+func copyParams(params map[string]interface{}) map[string]interface{} {
+	out := make(map[string]interface{}, len(params)+2)
+	for k, v := range params {
+		out[k] = v
+	}
+	return out
+}
+
+func toInt(v interface{}) (int, bool) {
+	switch n := v.(type) {
+	case float64:
+		return int(n), true
+	case int:
+		return n, true
+	case int64:
+		return int(n), true
+	}
+	return 0, false
+}
@@ -0,0 +1,43 @@
+package tavo
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"time"
+)
+
+// Artifact is an output produced by a job, such as a generated report, a
+// log or an export.
+type Artifact struct {
+	ID          string    `json:"id"`
+	Name        string    `json:"name"`
+	Size        int64     `json:"size"`
+	ContentType string    `json:"content_type"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// ListArtifacts lists the artifacts a job has produced.
+func (j *JobOperations) ListArtifacts(ctx context.Context, jobID string) ([]Artifact, error) {
+	resp, err := j.client.makeRequest(ctx, http.MethodGet, "/jobs/"+jobID+"/artifacts", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	items, _ := pageItems(resp, 0)
+	artifacts := make([]Artifact, 0, len(items))
+	for _, item := range items {
+		var a Artifact
+		if err := decodeMap(item, &a); err != nil {
+			return nil, err
+		}
+		artifacts = append(artifacts, a)
+	}
+	return artifacts, nil
+}
+
+// DownloadArtifact streams a job artifact's contents to w. The body is
+// verified against the server's X-Content-SHA256 checksum when one is
+// sent.
+func (j *JobOperations) DownloadArtifact(ctx context.Context, jobID, artifactID string, w io.Writer) error {
+	return j.client.download(ctx, "/jobs/"+jobID+"/artifacts/"+artifactID+"/download", "*/*", w)
+}
@@ -0,0 +1,65 @@
+package tavo
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestListArtifacts(t *testing.T) {
+	c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/jobs/j1/artifacts" {
+			t.Errorf("unexpected %s %s", r.Method, r.URL.Path)
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{"items": []map[string]interface{}{
+			{"id": "a1", "name": "report.pdf", "size": 2048, "content_type": "application/pdf", "created_at": "2024-05-01T10:00:00Z"},
+			{"id": "a2", "name": "job.log", "size": 17, "content_type": "text/plain", "created_at": "2024-05-01T10:01:00Z"},
+		}})
+	})
+
+	artifacts, err := c.Jobs().ListArtifacts(context.Background(), "j1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(artifacts) != 2 {
+		t.Fatalf("artifacts = %+v", artifacts)
+	}
+	a := artifacts[0]
+	if a.ID != "a1" || a.Name != "report.pdf" || a.Size != 2048 || a.ContentType != "application/pdf" ||
+		!a.CreatedAt.Equal(time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)) {
+		t.Errorf("artifact = %+v", a)
+	}
+}
+
+func TestDownloadArtifact(t *testing.T) {
+	c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/jobs/j1/artifacts/a2/download" {
+			t.Errorf("path = %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "text/plain")
+		w.Write([]byte("scan finished\n"))
+	})
+
+	var buf bytes.Buffer
+	if err := c.Jobs().DownloadArtifact(context.Background(), "j1", "a2", &buf); err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != "scan finished\n" {
+		t.Errorf("body = %q", buf.String())
+	}
+}
+
+func TestDownloadArtifactNotFound(t *testing.T) {
+	c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusNotFound, map[string]interface{}{"message": "no such artifact"})
+	})
+
+	err := c.Jobs().DownloadArtifact(context.Background(), "j1", "missing", &bytes.Buffer{})
+	var te *TavoError
+	if !errors.As(err, &te) || te.StatusCode != http.StatusNotFound {
+		t.Fatalf("err = %v", err)
+	}
+}
@@ -0,0 +1,191 @@
+package tavo
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// JobOperations groups the /jobs endpoints.
+type JobOperations struct {
+	client *Client
+}
+
+// ListJobs lists background jobs. Supported params include status, type,
+// scan_id, limit and offset.
+func (j *JobOperations) ListJobs(ctx context.Context, params map[string]interface{}) (map[string]interface{}, error) {
+	return j.client.makeRequest(ctx, http.MethodGet, "/jobs", nil, params)
+}
+
+// JobFilter narrows the jobs returned by ListJobsTyped. Zero values are
+// not sent.
+type JobFilter struct {
+	// Status matches any of the listed statuses.
+	Status        []string
+	Type          string
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
+	Limit         int
+	Offset        int
+}
+
+func (f JobFilter) params() map[string]interface{} {
+	params := map[string]interface{}{}
+	if len(f.Status) > 0 {
+		params["status"] = f.Status
+	}
+	if f.Type != "" {
+		params["type"] = f.Type
+	}
+	if !f.CreatedAfter.IsZero() {
+		params["created_after"] = f.CreatedAfter
+	}
+	if !f.CreatedBefore.IsZero() {
+		params["created_before"] = f.CreatedBefore
+	}
+	if f.Limit > 0 {
+		params["limit"] = f.Limit
+	}
+	if f.Offset > 0 {
+		params["offset"] = f.Offset
+	}
+	return params
+}
+
+// ListJobsTyped lists background jobs matching filter.
+func (j *JobOperations) ListJobsTyped(ctx context.Context, filter JobFilter) (map[string]interface{}, error) {
+	return j.ListJobs(ctx, filter.params())
+}
+
+// GetJob fetches a job by ID.
+func (j *JobOperations) GetJob(ctx context.Context, jobID string) (map[string]interface{}, error) {
+	return j.client.makeRequest(ctx, http.MethodGet, "/jobs/"+jobID, nil, nil)
+}
+
+// Job is a background job.
+type Job struct {
+	ID       string  `json:"id"`
+	Type     string  `json:"type"`
+	Status   string  `json:"status"`
+	Progress float64 `json:"progress"`
+	// Error describes why a failed job failed.
+	Error      string     `json:"error,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	StartedAt  *time.Time `json:"started_at,omitempty"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+}
+
+// IsTerminal reports whether the job has completed, failed or been
+// cancelled.
+func (j *Job) IsTerminal() bool {
+	return isTerminalJobStatus(j.Status)
+}
+
+// Succeeded reports whether the job completed successfully.
+func (j *Job) Succeeded() bool {
+	return j.Status == "completed"
+}
+
+// GetJobTyped fetches a job by ID as a Job.
+func (j *JobOperations) GetJobTyped(ctx context.Context, jobID string) (*Job, error) {
+	resp, err := j.GetJob(ctx, jobID)
+	if err != nil {
+		return nil, err
+	}
+	var job Job
+	if err := decodeMap(resp, &job); err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+// CancelJob stops a queued or running job.
+func (j *JobOperations) CancelJob(ctx context.Context, jobID string) (map[string]interface{}, error) {
+	return j.client.makeRequest(ctx, http.MethodPost, "/jobs/"+jobID+"/cancel", nil, nil)
+}
+
+// cancelConcurrency bounds the number of in-flight cancel requests.
+const cancelConcurrency = 8
+
+// cancelRounds bounds how often CancelAllForScan re-lists jobs to catch
+// ones spawned while it was cancelling.
+const cancelRounds = 3
+
+// CancelAllForScan cancels every unfinished job belonging to a scan,
+// concurrently, and returns the outcome per job ID (nil on success). Jobs
+// are re-listed after each round so jobs spawned mid-cancel are also
+// stopped. The error is non-nil only if the jobs could not be listed.
+func (j *JobOperations) CancelAllForScan(ctx context.Context, scanID string) (map[string]error, error) {
+	results := make(map[string]error)
+	var mu sync.Mutex
+
+	for round := 0; round < cancelRounds; round++ {
+		pending, err := j.activeJobsForScan(ctx, scanID, results)
+		if err != nil {
+			return results, err
+		}
+		if len(pending) == 0 {
+			break
+		}
+
+		sem := make(chan struct{}, cancelConcurrency)
+		var wg sync.WaitGroup
+		for _, id := range pending {
+			id := id
+			wg.Add(1)
+			sem <- struct{}{}
+			go func() {
+				defer wg.Done()
+				defer func() { <-sem }()
+				_, err := j.CancelJob(ctx, id)
+				mu.Lock()
+				results[id] = err
+				mu.Unlock()
+			}()
+		}
+		wg.Wait()
+	}
+	return results, nil
+}
+
+// activeJobsForScan lists the IDs of a scan's unfinished jobs, skipping
+// those already in seen. Jobs whose scan_id is not scanID are skipped too,
+// so a server that ignores the scan_id filter cannot get unrelated jobs
+// cancelled.
+func (j *JobOperations) activeJobsForScan(ctx context.Context, scanID string, seen map[string]error) ([]string, error) {
+	it := newIterator(ctx, func(ctx context.Context, offset int) ([]map[string]interface{}, int, error) {
+		resp, err := j.ListJobs(ctx, map[string]interface{}{
+			"scan_id": scanID,
+			"offset":  offset,
+			"limit":   DefaultPageSize,
+		})
+		if err != nil {
+			return nil, 0, err
+		}
+		items, total := pageItems(resp, offset)
+		return items, total, nil
+	})
+
+	var ids []string
+	for it.Next() {
+		job := it.Item()
+		id, _ := job["id"].(string)
+		if id == "" || job["scan_id"] != scanID || isTerminalJobStatus(job["status"]) {
+			continue
+		}
+		if _, done := seen[id]; done {
+			continue
+		}
+		ids = append(ids, id)
+	}
+	return ids, it.Err()
+}
+
+func isTerminalJobStatus(status interface{}) bool {
+	switch status {
+	case "completed", "failed", "cancelled":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,134 @@
+package tavo
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestCancelAllForScan(t *testing.T) {
+	var mu sync.Mutex
+	cancelled := map[string]bool{}
+	listCalls := 0
+	c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/jobs":
+			if got := r.URL.Query().Get("scan_id"); got != "scan-1" {
+				t.Errorf("scan_id = %q", got)
+			}
+			listCalls++
+			items := []map[string]interface{}{
+				{"id": "j1", "scan_id": "scan-1", "status": "running"},
+				{"id": "j2", "scan_id": "scan-1", "status": "queued"},
+				{"id": "j3", "scan_id": "scan-1", "status": "completed"},
+				// Jobs of other scans, as from a server ignoring the filter.
+				{"id": "x1", "scan_id": "scan-2", "status": "running"},
+				{"id": "x2", "status": "queued"},
+			}
+			// A job spawned while the first round was cancelling.
+			if listCalls > 1 {
+				items = append(items, map[string]interface{}{"id": "j4", "scan_id": "scan-1", "status": "queued"})
+			}
+			for _, item := range items {
+				if cancelled[item["id"].(string)] {
+					item["status"] = "cancelled"
+				}
+			}
+			writeJSON(w, http.StatusOK, map[string]interface{}{"items": items, "total": len(items)})
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/cancel"):
+			id := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/jobs/"), "/cancel")
+			if id == "j2" {
+				writeJSON(w, http.StatusConflict, map[string]interface{}{"message": "already finishing"})
+				return
+			}
+			if strings.HasPrefix(id, "x") {
+				t.Errorf("cancelled unrelated job %s", id)
+			}
+			cancelled[id] = true
+			writeJSON(w, http.StatusOK, map[string]interface{}{"id": id, "status": "cancelled"})
+		default:
+			t.Errorf("unexpected %s %s", r.Method, r.URL.Path)
+		}
+	})
+
+	results, err := c.Jobs().CancelAllForScan(context.Background(), "scan-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 3 {
+		t.Fatalf("results = %v", results)
+	}
+	if results["j1"] != nil || results["j4"] != nil {
+		t.Fatalf("results = %v", results)
+	}
+	if results["j2"] == nil {
+		t.Fatal("expected j2 to report its cancel failure")
+	}
+	if _, ok := results["j3"]; ok {
+		t.Fatal("completed job should not be cancelled")
+	}
+}
+
+func TestListJobsTyped(t *testing.T) {
+	c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if got := q["status"]; len(got) != 2 || got[0] != "queued" || got[1] != "running" {
+			t.Errorf("status = %v", got)
+		}
+		if q.Get("type") != "scan" || q.Get("created_after") != "2025-01-02T03:04:05Z" || q.Get("limit") != "10" {
+			t.Errorf("query = %v", q)
+		}
+		for _, absent := range []string{"created_before", "offset"} {
+			if q.Has(absent) {
+				t.Errorf("%s sent for zero value", absent)
+			}
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{"items": []interface{}{}, "total": 0})
+	})
+
+	after := time.Date(2025, 1, 2, 4, 4, 5, 0, time.FixedZone("CET", 3600))
+	_, err := c.Jobs().ListJobsTyped(context.Background(), JobFilter{
+		Status:       []string{"queued", "running"},
+		Type:         "scan",
+		CreatedAfter: after,
+		Limit:        10,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestGetJobTyped(t *testing.T) {
+	c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/jobs/done":
+			writeJSON(w, http.StatusOK, map[string]interface{}{
+				"id": "done", "type": "scan", "status": "completed", "progress": 1.0,
+				"created_at": "2025-01-01T00:00:00Z", "started_at": "2025-01-01T00:00:01Z", "finished_at": "2025-01-01T00:05:00Z",
+			})
+		case "/jobs/broken":
+			writeJSON(w, http.StatusOK, map[string]interface{}{"id": "broken", "status": "failed", "error": "out of memory"})
+		case "/jobs/busy":
+			writeJSON(w, http.StatusOK, map[string]interface{}{"id": "busy", "status": "running", "progress": 0.4})
+		}
+	})
+	ctx := context.Background()
+
+	done, err := c.Jobs().GetJobTyped(ctx, "done")
+	if err != nil || !done.IsTerminal() || !done.Succeeded() || done.FinishedAt == nil || done.FinishedAt.Sub(*done.StartedAt) != 4*time.Minute+59*time.Second {
+		t.Fatalf("done = %+v, err = %v", done, err)
+	}
+	broken, _ := c.Jobs().GetJobTyped(ctx, "broken")
+	if !broken.IsTerminal() || broken.Succeeded() || broken.Error != "out of memory" {
+		t.Fatalf("broken = %+v", broken)
+	}
+	busy, _ := c.Jobs().GetJobTyped(ctx, "busy")
+	if busy.IsTerminal() || busy.Progress != 0.4 || busy.StartedAt != nil {
+		t.Fatalf("busy = %+v", busy)
+	}
+}
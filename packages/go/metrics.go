@@ -0,0 +1,12 @@
+package tavo
+
+import "time"
+
+// Metrics receives one observation per HTTP attempt. Implementations must
+// be safe for concurrent use. See the tavoprom package for a Prometheus
+// implementation.
+type Metrics interface {
+	// ObserveRequest records an attempt. path is the request path without
+	// query parameters; status is 0 when no response was received.
+	ObserveRequest(method, path string, status int, duration time.Duration)
+}
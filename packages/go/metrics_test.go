@@ -0,0 +1,122 @@
+package tavo
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+type observation struct {
+	method, path string
+	status       int
+}
+
+type recordingMetrics struct {
+	mu  sync.Mutex
+	obs []observation
+}
+
+func (m *recordingMetrics) ObserveRequest(method, path string, status int, d time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.obs = append(m.obs, observation{method, path, status})
+}
+
+func TestMetricsObserveEveryAttempt(t *testing.T) {
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{})
+	}))
+	defer srv.Close()
+	m := &recordingMetrics{}
+	c := newTestClientFor(t, srv, func(cfg *Config) { cfg.WithMetrics(m) })
+
+	if _, err := c.Jobs().GetJob(context.Background(), "j1"); err != nil {
+		t.Fatal(err)
+	}
+	want := []observation{{"GET", "/jobs/j1", 502}, {"GET", "/jobs/j1", 200}}
+	if len(m.obs) != 2 || m.obs[0] != want[0] || m.obs[1] != want[1] {
+		t.Fatalf("observations = %v", m.obs)
+	}
+}
+
+func TestMetricsNetworkErrorStatusZero(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	srv.Close()
+	m := &recordingMetrics{}
+	c := newTestClientFor(t, srv, func(cfg *Config) { cfg.WithMetrics(m).WithMaxRetries(0) })
+
+	if _, err := c.Jobs().GetJob(context.Background(), "j1"); err == nil {
+		t.Fatal("expected network error")
+	}
+	if len(m.obs) != 1 || m.obs[0].status != 0 {
+		t.Fatalf("observations = %v", m.obs)
+	}
+}
+
+func TestMetricsObserveRawAndStreamedRequests(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/reports/r1/download":
+			w.Write([]byte("report"))
+		case "/api/v1/scans/s1/results":
+			writeJSON(w, http.StatusOK, []interface{}{})
+		case "/api/v1/scans/upload":
+			io.Copy(io.Discard, r.Body)
+			writeJSON(w, http.StatusCreated, map[string]interface{}{"id": "s2"})
+		case "/api/v1/ai/analyze":
+			w.Header().Set("Content-Type", "text/event-stream")
+			fmt.Fprint(w, "data: {\"type\":\"complete\"}\n\n")
+		default:
+			t.Errorf("unexpected %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer srv.Close()
+	m := &recordingMetrics{}
+	c := newTestClientFor(t, srv, func(cfg *Config) { cfg.WithMetrics(m) })
+	ctx := context.Background()
+
+	if err := c.Reports().DownloadReportTo(ctx, "r1", io.Discard); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Scans().StreamScanResults(ctx, "s1", func(map[string]interface{}) error { return nil }); err != nil {
+		t.Fatal(err)
+	}
+	archive := filepath.Join(t.TempDir(), "repo.zip")
+	if err := os.WriteFile(archive, []byte("zip"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Scans().UploadAndScan(ctx, archive, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.AI().AnalyzeCodeStream(ctx, nil, make(chan AnalysisEvent, 1)); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []observation{
+		{"GET", "/reports/r1/download", 200},
+		{"GET", "/scans/s1/results", 200},
+		{"POST", "/scans/upload", 201},
+		{"POST", "/ai/analyze", 200},
+	}
+	if len(m.obs) != len(want) {
+		t.Fatalf("observations = %v", m.obs)
+	}
+	for i := range want {
+		if m.obs[i] != want[i] {
+			t.Fatalf("observations = %v, want %v", m.obs, want)
+		}
+	}
+}
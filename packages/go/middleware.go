@@ -0,0 +1,25 @@
+package tavo
+
+import "net/http"
+
+// RoundTripFunc sends one HTTP request. It is the unit middlewares wrap.
+type RoundTripFunc func(*http.Request) (*http.Response, error)
+
+// RoundTrip implements http.RoundTripper.
+func (f RoundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// Middleware wraps a RoundTripFunc, for example to record latency, export
+// metrics or start a tracing span. It sees every attempt, including
+// retries, along with the final status code or transport error.
+type Middleware func(next RoundTripFunc) RoundTripFunc
+
+// chainMiddlewares wraps base so that mws[0] is the outermost layer.
+func chainMiddlewares(base http.RoundTripper, mws []Middleware) http.RoundTripper {
+	next := RoundTripFunc(base.RoundTrip)
+	for i := len(mws) - 1; i >= 0; i-- {
+		next = mws[i](next)
+	}
+	return next
+}
@@ -0,0 +1,49 @@
+package tavo
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestMiddlewaresComposeInOrder(t *testing.T) {
+	c, srv := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Trace") != "outer,inner" {
+			t.Errorf("X-Trace = %q", r.Header.Get("X-Trace"))
+		}
+		writeJSON(w, http.StatusNotFound, map[string]interface{}{"message": "nope"})
+	})
+
+	var events []string
+	record := func(name string) Middleware {
+		return func(next RoundTripFunc) RoundTripFunc {
+			return func(req *http.Request) (*http.Response, error) {
+				events = append(events, name+":before")
+				if prev := req.Header.Get("X-Trace"); prev != "" {
+					req.Header.Set("X-Trace", prev+","+name)
+				} else {
+					req.Header.Set("X-Trace", name)
+				}
+				resp, err := next(req)
+				if err != nil {
+					events = append(events, name+":error")
+				} else {
+					events = append(events, name+":"+http.StatusText(resp.StatusCode))
+				}
+				return resp, err
+			}
+		}
+	}
+	c = newTestClientFor(t, srv, func(cfg *Config) {
+		cfg.WithRoundTripper(record("outer")).WithRoundTripper(record("inner"))
+	})
+
+	if _, err := c.Jobs().GetJob(context.Background(), "j1"); err == nil {
+		t.Fatal("expected 404 error")
+	}
+	want := []string{"outer:before", "inner:before", "inner:Not Found", "outer:Not Found"}
+	if !reflect.DeepEqual(events, want) {
+		t.Fatalf("events = %v, want %v", events, want)
+	}
+}
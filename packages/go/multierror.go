@@ -0,0 +1,51 @@
+package tavo
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MultiError aggregates the failures of a batch operation. errors.Is and
+// errors.As look through every aggregated error, so
+// errors.Is(err, ErrNotFound) holds when any item was not found; use
+// Errors to inspect them one by one.
+type MultiError struct {
+	errs []error
+}
+
+// newMultiError returns a *MultiError holding the non-nil errs, or nil
+// when there are none.
+func newMultiError(errs []error) error {
+	var kept []error
+	for _, err := range errs {
+		if err != nil {
+			kept = append(kept, err)
+		}
+	}
+	if len(kept) == 0 {
+		return nil
+	}
+	return &MultiError{errs: kept}
+}
+
+// Error lists every aggregated error.
+func (m *MultiError) Error() string {
+	if len(m.errs) == 1 {
+		return m.errs[0].Error()
+	}
+	msgs := make([]string, len(m.errs))
+	for i, err := range m.errs {
+		msgs[i] = err.Error()
+	}
+	return fmt.Sprintf("tavo: %d errors: %s", len(m.errs), strings.Join(msgs, "; "))
+}
+
+// Errors returns the aggregated errors in the order they were recorded.
+func (m *MultiError) Errors() []error {
+	return append([]error(nil), m.errs...)
+}
+
+// Unwrap returns the aggregated errors for errors.Is and errors.As.
+func (m *MultiError) Unwrap() []error {
+	return m.errs
+}
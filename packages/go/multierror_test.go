@@ -0,0 +1,41 @@
+package tavo
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestMultiError(t *testing.T) {
+	if err := newMultiError([]error{nil, nil}); err != nil {
+		t.Fatalf("newMultiError of nils = %v", err)
+	}
+
+	notFound := &TavoError{StatusCode: 404, Message: "no such rule"}
+	other := errors.New("connection reset")
+	err := newMultiError([]error{fmt.Errorf("rule r1: %w", notFound), nil, other})
+
+	var multi *MultiError
+	if !errors.As(err, &multi) || len(multi.Errors()) != 2 {
+		t.Fatalf("err = %#v", err)
+	}
+	if !errors.Is(err, ErrNotFound) || !errors.Is(err, other) || errors.Is(err, ErrServer) {
+		t.Fatal("errors.Is does not see through the MultiError")
+	}
+	var tErr *TavoError
+	if !errors.As(err, &tErr) || tErr != notFound {
+		t.Fatalf("errors.As = %v", tErr)
+	}
+	want := "tavo: 2 errors: rule r1: tavo: no such rule (status 404); connection reset"
+	if err.Error() != want {
+		t.Fatalf("Error() = %q, want %q", err.Error(), want)
+	}
+
+	multi.Errors()[0] = nil
+	if multi.Errors()[0] == nil {
+		t.Fatal("Errors exposes internal state")
+	}
+	if single := newMultiError([]error{other}); single.Error() != other.Error() {
+		t.Fatalf("single error message = %q", single.Error())
+	}
+}
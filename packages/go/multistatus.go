@@ -0,0 +1,131 @@
+package tavo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// ItemResult is the outcome of one item of a bulk request.
+type ItemResult struct {
+	// Index is the item's position in the request.
+	Index int
+	// ID identifies the affected resource when the server reports one.
+	ID string
+	// Status is the item's HTTP-style status code.
+	Status int
+	// Data is the item's response body on success.
+	Data map[string]interface{}
+	// Err is set when the item failed.
+	Err *TavoError
+}
+
+// OK reports whether the item succeeded.
+func (r ItemResult) OK() bool { return r.Err == nil && r.Status >= 200 && r.Status <= 299 }
+
+// MultiStatusResult holds the per-item outcomes of a bulk request. The API
+// answers 207 Multi-Status when only some items succeeded.
+type MultiStatusResult struct {
+	StatusCode int
+	Results    []ItemResult
+}
+
+// Succeeded returns the items that succeeded.
+func (m *MultiStatusResult) Succeeded() []ItemResult {
+	var out []ItemResult
+	for _, r := range m.Results {
+		if r.OK() {
+			out = append(out, r)
+		}
+	}
+	return out
+}
+
+// Failed returns the items that failed.
+func (m *MultiStatusResult) Failed() []ItemResult {
+	var out []ItemResult
+	for _, r := range m.Results {
+		if !r.OK() {
+			out = append(out, r)
+		}
+	}
+	return out
+}
+
+// Err returns a *MultiError holding each failed item's error, prefixed
+// with its index, or nil when every item succeeded.
+func (m *MultiStatusResult) Err() error {
+	var errs []error
+	for _, r := range m.Failed() {
+		errs = append(errs, fmt.Errorf("item %d: %w", r.Index, r.Err))
+	}
+	return newMultiError(errs)
+}
+
+// Partial reports whether some, but not all, items failed.
+func (m *MultiStatusResult) Partial() bool {
+	failed := len(m.Failed())
+	return failed > 0 && failed < len(m.Results)
+}
+
+// makeBulkRequest sends a bulk request and decodes its per-item results.
+// 207 and other 2xx responses return a MultiStatusResult; other statuses
+// return a *TavoError.
+func (c *Client) makeBulkRequest(ctx context.Context, method, path string, body interface{}) (*MultiStatusResult, error) {
+	resp, err := c.execute(ctx, &apiRequest{method: method, path: path, body: body})
+	if err != nil {
+		return nil, err
+	}
+	status := resp.StatusCode()
+	if status < 200 || status > 299 {
+		return nil, responseError(status, resp.Header(), resp.Body())
+	}
+	return parseMultiStatus(status, resp.Body())
+}
+
+// parseMultiStatus decodes a body of the form {"results": [...]}. Items
+// without a status inherit the response status, except on 207 where an item
+// carrying an error defaults to 500.
+func parseMultiStatus(status int, body []byte) (*MultiStatusResult, error) {
+	result := &MultiStatusResult{StatusCode: status}
+	if len(body) == 0 {
+		return result, nil
+	}
+	var envelope struct {
+		Results []json.RawMessage `json:"results"`
+	}
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		return nil, fmt.Errorf("tavo: decoding multi-status response: %w", err)
+	}
+
+	for i, raw := range envelope.Results {
+		var item struct {
+			Index  *int                   `json:"index"`
+			ID     string                 `json:"id"`
+			Status int                    `json:"status"`
+			Data   map[string]interface{} `json:"data"`
+			Error  json.RawMessage        `json:"error"`
+		}
+		if err := json.Unmarshal(raw, &item); err != nil {
+			return nil, fmt.Errorf("tavo: decoding multi-status item %d: %w", i, err)
+		}
+
+		r := ItemResult{Index: i, ID: item.ID, Status: item.Status, Data: item.Data}
+		if item.Index != nil {
+			r.Index = *item.Index
+		}
+		hasError := len(item.Error) > 0 && string(item.Error) != "null"
+		if r.Status == 0 {
+			r.Status = status
+			if hasError && status == http.StatusMultiStatus {
+				r.Status = http.StatusInternalServerError
+			}
+		}
+		if hasError || r.Status < 200 || r.Status > 299 {
+			r.Err = newTavoError(r.Status, raw)
+		}
+		result.Results = append(result.Results, r)
+	}
+	return result, nil
+}
@@ -0,0 +1,75 @@
+package tavo
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestBulkCreateRulesPartialSuccess(t *testing.T) {
+	c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/scan-rules/bulk" {
+			t.Errorf("path = %s", r.URL.Path)
+		}
+		writeJSON(w, http.StatusMultiStatus, map[string]interface{}{
+			"results": []map[string]interface{}{
+				{"index": 0, "id": "r1", "status": 201, "data": map[string]interface{}{"id": "r1"}},
+				{"index": 1, "status": 422, "error": map[string]interface{}{"code": "invalid_pattern", "message": "bad regex"}},
+				{"index": 2, "error": "duplicate slug"},
+			},
+		})
+	})
+
+	res, err := c.ScanRules().BulkCreateRules(context.Background(), []map[string]interface{}{{}, {}, {}})
+	var multi *MultiError
+	if !errors.As(err, &multi) || len(multi.Errors()) != 2 {
+		t.Fatalf("err = %v, want a MultiError of the two rejected rules", err)
+	}
+	if !errors.Is(err, ErrServer) || !strings.Contains(err.Error(), "item 1: ") {
+		t.Fatalf("err = %v", err)
+	}
+	if res.StatusCode != http.StatusMultiStatus || !res.Partial() {
+		t.Fatalf("res = %+v", res)
+	}
+	if ok := res.Succeeded(); len(ok) != 1 || ok[0].ID != "r1" || ok[0].Data["id"] != "r1" {
+		t.Fatalf("succeeded = %+v", ok)
+	}
+	failed := res.Failed()
+	if len(failed) != 2 {
+		t.Fatalf("failed = %+v", failed)
+	}
+	if failed[0].Err.Code != "invalid_pattern" || failed[0].Status != 422 {
+		t.Fatalf("failed[0] = %+v", failed[0].Err)
+	}
+	if failed[1].Err.Message != "duplicate slug" || failed[1].Status != 500 {
+		t.Fatalf("failed[1] = %+v, %+v", failed[1], failed[1].Err)
+	}
+}
+
+func TestBulkCreateRulesFullFailureIsError(t *testing.T) {
+	c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusBadRequest, map[string]interface{}{"message": "rules must not be empty"})
+	})
+
+	if _, err := c.ScanRules().BulkCreateRules(context.Background(), nil); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestBulkCreateRulesAllSucceeded(t *testing.T) {
+	c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusCreated, map[string]interface{}{
+			"results": []map[string]interface{}{{"id": "r1"}, {"id": "r2"}},
+		})
+	})
+
+	res, err := c.ScanRules().BulkCreateRules(context.Background(), []map[string]interface{}{{}, {}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Succeeded()) != 2 {
+		t.Fatalf("res = %+v", res)
+	}
+}
@@ -0,0 +1,62 @@
+package tavo
+
+import "context"
+
+// RequestOption customizes a single API call. Options are attached to a
+// context with WithRequestOptions and apply to every call made with it:
+//
+//	ctx := tavo.WithRequestOptions(ctx, tavo.WithNoRetry())
+//	scan, err := client.Scans().CreateScan(ctx, data)
+type RequestOption func(*apiRequest)
+
+type requestOptionsKey struct{}
+
+// WithRequestOptions returns a copy of ctx carrying opts in addition to any
+// options already attached to ctx. Later options win.
+func WithRequestOptions(ctx context.Context, opts ...RequestOption) context.Context {
+	existing := requestOptionsFromContext(ctx)
+	merged := make([]RequestOption, 0, len(existing)+len(opts))
+	merged = append(merged, existing...)
+	merged = append(merged, opts...)
+	return context.WithValue(ctx, requestOptionsKey{}, merged)
+}
+
+func requestOptionsFromContext(ctx context.Context) []RequestOption {
+	opts, _ := ctx.Value(requestOptionsKey{}).([]RequestOption)
+	return opts
+}
+
+// WithNoRetry sends the call exactly once, regardless of the client's
+// MaxRetries. Use it for non-idempotent calls or when retrying at a higher
+// layer.
+func WithNoRetry() RequestOption {
+	return func(r *apiRequest) { r.noRetry = true }
+}
+
+// WithSnippetContext asks result endpoints to include lines of source on
+// each side of every finding, populating Finding.Snippet.
+func WithSnippetContext(lines int) RequestOption {
+	return withParam("snippet_context", lines)
+}
+
+// WithHeader sets a header on the call only, overriding any default header
+// with the same key.
+func WithHeader(key, value string) RequestOption {
+	return func(r *apiRequest) {
+		headers := make(map[string]string, len(r.headers)+1)
+		for k, v := range r.headers {
+			headers[k] = v
+		}
+		headers[key] = value
+		r.headers = headers
+	}
+}
+
+// withParam sets a query parameter without mutating the caller's map.
+func withParam(key string, value interface{}) RequestOption {
+	return func(r *apiRequest) {
+		params := copyParams(r.params)
+		params[key] = value
+		r.params = params
+	}
+}
@@ -0,0 +1,66 @@
+package tavo
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func TestWithNoRetrySendsOnce(t *testing.T) {
+	var calls int32
+	c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusBadGateway)
+	})
+
+	ctx := WithRequestOptions(context.Background(), WithNoRetry())
+	if _, err := c.Scans().CreateScan(ctx, map[string]interface{}{"name": "x"}); err == nil {
+		t.Fatal("expected error")
+	}
+	if calls != 1 {
+		t.Fatalf("calls = %d, want 1", calls)
+	}
+
+	// Calls without the option keep the client's retry policy.
+	atomic.StoreInt32(&calls, 0)
+	if _, err := c.Scans().CreateScan(context.Background(), map[string]interface{}{"name": "x"}); err == nil {
+		t.Fatal("expected error")
+	}
+	if want := int32(DefaultMaxRetries + 1); calls != want {
+		t.Fatalf("calls = %d, want %d", calls, want)
+	}
+}
+
+func TestDoWithHeaderOverridesDefaults(t *testing.T) {
+	var seen []http.Header
+	srv := httptest.NewServer(apiHandler(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/experiments" || r.URL.Query().Get("dry_run") != "true" {
+			t.Errorf("unexpected %s %s", r.Method, r.URL)
+		}
+		seen = append(seen, r.Header.Clone())
+		writeJSON(w, http.StatusOK, map[string]interface{}{"ok": true})
+	}))
+	defer srv.Close()
+	c := newTestClientFor(t, srv, func(cfg *Config) {
+		cfg.WithDefaultHeaders(map[string]string{"X-Team": "platform", "X-Experiment": "control"})
+	})
+	ctx := context.Background()
+	params := map[string]interface{}{"dry_run": true}
+
+	resp, err := c.Do(ctx, http.MethodPost, "/experiments", map[string]interface{}{}, params, WithHeader("X-Experiment", "variant-b"))
+	if err != nil || resp["ok"] != true {
+		t.Fatalf("resp = %v, err = %v", resp, err)
+	}
+	if _, err := c.Do(ctx, http.MethodPost, "/experiments", nil, params); err != nil {
+		t.Fatal(err)
+	}
+
+	if seen[0].Get("X-Experiment") != "variant-b" || seen[0].Get("X-Team") != "platform" {
+		t.Errorf("first request headers = %v", seen[0])
+	}
+	if seen[1].Get("X-Experiment") != "control" {
+		t.Errorf("per-request header leaked into the next call: %v", seen[1])
+	}
+}
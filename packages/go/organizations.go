@@ -0,0 +1,143 @@
+package tavo
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// OrganizationOperations groups the /organizations endpoints.
+type OrganizationOperations struct {
+	client *Client
+}
+
+// ListOrganizations lists the organizations the caller belongs to.
+func (o *OrganizationOperations) ListOrganizations(ctx context.Context, params map[string]interface{}) (map[string]interface{}, error) {
+	return o.client.makeRequest(ctx, http.MethodGet, "/organizations", nil, params)
+}
+
+// GetOrganization fetches an organization by ID.
+func (o *OrganizationOperations) GetOrganization(ctx context.Context, orgID string) (map[string]interface{}, error) {
+	return o.client.makeRequest(ctx, http.MethodGet, "/organizations/"+orgID, nil, nil)
+}
+
+// Organization is a Tavo organization with its plan and scan quota.
+type Organization struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Plan        string `json:"plan"`
+	MemberCount int    `json:"member_count"`
+	// ScanQuota is the number of scans allowed in the current billing
+	// period and ScansUsed the number already run.
+	ScanQuota int       `json:"scan_quota"`
+	ScansUsed int       `json:"scans_used"`
+	CreatedAt time.Time `json:"created_at"`
+
+	// Extra holds response fields without a dedicated field above.
+	Extra map[string]interface{} `json:"-"`
+}
+
+// QuotaRemaining returns how many scans are left in the current billing
+// period. It is never negative, even when usage exceeds the quota.
+func (o *Organization) QuotaRemaining() int {
+	if o.ScansUsed >= o.ScanQuota {
+		return 0
+	}
+	return o.ScanQuota - o.ScansUsed
+}
+
+// GetOrganizationTyped fetches an organization by ID as an Organization.
+func (o *OrganizationOperations) GetOrganizationTyped(ctx context.Context, orgID string) (*Organization, error) {
+	resp, err := o.GetOrganization(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	var org Organization
+	if err := decodeMap(resp, &org); err != nil {
+		return nil, err
+	}
+	org.Extra = extraFields(resp, &org)
+	return &org, nil
+}
+
+// CreateOrganization creates an organization.
+func (o *OrganizationOperations) CreateOrganization(ctx context.Context, data map[string]interface{}) (map[string]interface{}, error) {
+	return o.client.makeRequest(ctx, http.MethodPost, "/organizations", data, nil)
+}
+
+// UpdateOrganization updates an organization.
+func (o *OrganizationOperations) UpdateOrganization(ctx context.Context, orgID string, data map[string]interface{}) (map[string]interface{}, error) {
+	return o.client.makeRequest(ctx, http.MethodPut, "/organizations/"+orgID, data, nil)
+}
+
+// MemberRoles are the roles an organization member can hold.
+var MemberRoles = []string{"owner", "admin", "member", "viewer"}
+
+func validateMemberRole(role string) error {
+	for _, r := range MemberRoles {
+		if role == r {
+			return nil
+		}
+	}
+	return fmt.Errorf("tavo: unknown member role %q (want one of %v)", role, MemberRoles)
+}
+
+// Member is a user's membership in an organization.
+type Member struct {
+	UserID   string    `json:"user_id"`
+	Role     string    `json:"role"`
+	JoinedAt time.Time `json:"joined_at"`
+}
+
+// AddMember adds a user to an organization with the given role.
+func (o *OrganizationOperations) AddMember(ctx context.Context, orgID, userID, role string) (map[string]interface{}, error) {
+	if err := validateMemberRole(role); err != nil {
+		return nil, err
+	}
+	data := map[string]interface{}{"user_id": userID, "role": role}
+	return o.client.makeRequest(ctx, http.MethodPost, "/organizations/"+orgID+"/members", data, nil)
+}
+
+// RemoveMember removes a user from an organization.
+func (o *OrganizationOperations) RemoveMember(ctx context.Context, orgID, userID string) error {
+	_, err := o.client.makeRequest(ctx, http.MethodDelete, "/organizations/"+orgID+"/members/"+userID, nil, nil)
+	return err
+}
+
+// ListMembers lists an organization's members. Supported params include
+// role, limit and offset.
+func (o *OrganizationOperations) ListMembers(ctx context.Context, orgID string, params map[string]interface{}) ([]Member, error) {
+	resp, err := o.client.makeRequest(ctx, http.MethodGet, "/organizations/"+orgID+"/members", nil, params)
+	if err != nil {
+		return nil, err
+	}
+	offset, _ := toInt(params["offset"])
+	items, _ := pageItems(resp, offset)
+	members := make([]Member, 0, len(items))
+	for _, item := range items {
+		var m Member
+		if err := decodeMap(item, &m); err != nil {
+			return nil, err
+		}
+		members = append(members, m)
+	}
+	return members, nil
+}
+
+// UpdateMemberRole changes a member's role. The role is checked against
+// MemberRoles before anything is sent.
+func (o *OrganizationOperations) UpdateMemberRole(ctx context.Context, orgID, userID, role string) (*Member, error) {
+	if err := validateMemberRole(role); err != nil {
+		return nil, err
+	}
+	resp, err := o.client.makeRequest(ctx, http.MethodPatch, "/organizations/"+orgID+"/members/"+userID, map[string]interface{}{"role": role}, nil)
+	if err != nil {
+		return nil, err
+	}
+	var m Member
+	if err := decodeMap(resp, &m); err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
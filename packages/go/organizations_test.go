@@ -0,0 +1,93 @@
+package tavo
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestListMembers(t *testing.T) {
+	c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/organizations/o1/members" || r.URL.Query().Get("role") != "admin" {
+			t.Errorf("unexpected %s", r.URL)
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"items": []map[string]interface{}{
+				{"user_id": "u1", "role": "admin", "joined_at": "2024-06-01T00:00:00Z"},
+				{"user_id": "u2", "role": "admin", "joined_at": "2024-07-01T00:00:00Z"},
+			},
+			"total": 2,
+		})
+	})
+
+	members, err := c.Organizations().ListMembers(context.Background(), "o1", map[string]interface{}{"role": "admin"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(members) != 2 || members[1].UserID != "u2" || members[0].JoinedAt.Month() != 6 {
+		t.Fatalf("members = %+v", members)
+	}
+}
+
+func TestUpdateMemberRole(t *testing.T) {
+	c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch || r.URL.Path != "/organizations/o1/members/u1" {
+			t.Errorf("unexpected %s %s", r.Method, r.URL.Path)
+		}
+		var body map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&body)
+		writeJSON(w, http.StatusOK, map[string]interface{}{"user_id": "u1", "role": body["role"]})
+	})
+
+	m, err := c.Organizations().UpdateMemberRole(context.Background(), "o1", "u1", "viewer")
+	if err != nil || m.Role != "viewer" || m.UserID != "u1" {
+		t.Fatalf("member = %+v, err = %v", m, err)
+	}
+}
+
+func TestMemberRoleValidation(t *testing.T) {
+	c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("request sent for invalid role: %s %s", r.Method, r.URL.Path)
+	})
+	ctx := context.Background()
+	if _, err := c.Organizations().UpdateMemberRole(ctx, "o1", "u1", "superuser"); err == nil {
+		t.Error("UpdateMemberRole accepted unknown role")
+	}
+	if _, err := c.Organizations().AddMember(ctx, "o1", "u1", "Admin"); err == nil {
+		t.Error("AddMember accepted unknown role")
+	}
+}
+
+func TestGetOrganizationTyped(t *testing.T) {
+	c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/organizations/o1" {
+			t.Errorf("path = %s", r.URL.Path)
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"id": "o1", "name": "Acme", "plan": "team", "member_count": 12,
+			"scan_quota": 500, "scans_used": 480, "created_at": "2024-01-02T03:04:05Z",
+			"billing_email": "ops@acme.test",
+		})
+	})
+	org, err := c.Organizations().GetOrganizationTyped(context.Background(), "o1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if org.Name != "Acme" || org.Plan != "team" || org.MemberCount != 12 || org.CreatedAt.Year() != 2024 {
+		t.Fatalf("org = %+v", org)
+	}
+	if got := org.QuotaRemaining(); got != 20 {
+		t.Fatalf("QuotaRemaining = %d, want 20", got)
+	}
+	if org.Extra["billing_email"] != "ops@acme.test" || len(org.Extra) != 1 {
+		t.Fatalf("Extra = %v", org.Extra)
+	}
+}
+
+func TestQuotaRemainingNeverNegative(t *testing.T) {
+	org := Organization{ScanQuota: 100, ScansUsed: 130}
+	if got := org.QuotaRemaining(); got != 0 {
+		t.Fatalf("QuotaRemaining = %d, want 0", got)
+	}
+}
@@ -0,0 +1,43 @@
+package tavo
+
+// Page is one page of a list endpoint, whose responses have the form
+// {"items": [...], "total": n, "offset": n, "limit": n}.
+type Page[T any] struct {
+	Items  []T `json:"items"`
+	Total  int `json:"total"`
+	Offset int `json:"offset"`
+	Limit  int `json:"limit"`
+}
+
+// HasMore reports whether items remain after this page.
+func (p *Page[T]) HasMore() bool {
+	return len(p.Items) > 0 && p.Offset+len(p.Items) < p.Total
+}
+
+// NextOffset is the offset of the page after this one.
+func (p *Page[T]) NextOffset() int {
+	return p.Offset + len(p.Items)
+}
+
+// decodePage decodes a list response. Metadata the server omits is taken
+// from the request params: offset and limit as sent, and a total that ends
+// pagination after this page.
+func decodePage[T any](resp map[string]interface{}, params map[string]interface{}) (*Page[T], error) {
+	var page Page[T]
+	if err := decodeMap(resp, &page); err != nil {
+		return nil, err
+	}
+	if _, ok := resp["offset"]; !ok {
+		page.Offset, _ = toInt(params["offset"])
+	}
+	if _, ok := resp["limit"]; !ok {
+		page.Limit, _ = toInt(params["limit"])
+	}
+	if _, ok := resp["total"]; !ok {
+		page.Total = page.Offset + len(page.Items)
+	}
+	if page.Items == nil {
+		page.Items = []T{}
+	}
+	return &page, nil
+}
@@ -0,0 +1,53 @@
+package tavo
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestListScansPaged(t *testing.T) {
+	c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("offset") {
+		case "0":
+			writeJSON(w, http.StatusOK, map[string]interface{}{
+				"items": []map[string]interface{}{{"id": "a"}, {"id": "b"}}, "total": 3, "offset": 0, "limit": 2,
+			})
+		case "2":
+			// No metadata: it is filled in from the request.
+			writeJSON(w, http.StatusOK, map[string]interface{}{"items": []map[string]interface{}{{"id": "c"}}})
+		}
+	})
+	ctx := context.Background()
+
+	page, err := c.Scans().ListScansPaged(ctx, map[string]interface{}{"offset": 0, "limit": 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(page.Items) != 2 || page.Items[1]["id"] != "b" || page.Total != 3 || page.Limit != 2 || !page.HasMore() || page.NextOffset() != 2 {
+		t.Fatalf("page = %+v", page)
+	}
+
+	page, err = c.Scans().ListScansPaged(ctx, map[string]interface{}{"offset": page.NextOffset(), "limit": 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(page.Items) != 1 || page.Offset != 2 || page.Limit != 2 || page.Total != 3 || page.HasMore() {
+		t.Fatalf("page = %+v", page)
+	}
+}
+
+func TestPageHasMore(t *testing.T) {
+	for _, tc := range []struct {
+		page Page[int]
+		want bool
+	}{
+		{Page[int]{Items: []int{1, 2}, Total: 5}, true},
+		{Page[int]{Items: []int{4, 5}, Offset: 3, Total: 5}, false},
+		{Page[int]{Items: nil, Offset: 0, Total: 5}, false},
+	} {
+		if got := tc.page.HasMore(); got != tc.want {
+			t.Errorf("%+v: HasMore = %v", tc.page, got)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package tavo
+
+import (
+	"context"
+	"net/http"
+)
+
+// PolicyResult is the outcome of checking a scan against an org policy.
+type PolicyResult struct {
+	PolicyID   string        `json:"policy_id"`
+	Passed     bool          `json:"passed"`
+	Violations []Violation   `json:"violations"`
+	Summary    PolicySummary `json:"summary"`
+}
+
+// Violation is one policy threshold the scan exceeded.
+type Violation struct {
+	RuleID   string `json:"rule_id"`
+	Severity string `json:"severity"`
+	Message  string `json:"message"`
+	// Threshold is the number of matching findings the policy allows and
+	// Actual the number the scan reported.
+	Threshold  int      `json:"threshold"`
+	Actual     int      `json:"actual"`
+	FindingIDs []string `json:"finding_ids,omitempty"`
+}
+
+// PolicySummary counts the scan's findings as seen by the policy.
+type PolicySummary struct {
+	TotalFindings int            `json:"total_findings"`
+	BySeverity    map[string]int `json:"by_severity,omitempty"`
+}
+
+// EvaluatePolicy checks a scan against a policy. A scan that fails the
+// policy is not an error: the result has Passed set to false and lists
+// the violations, so CI can fail the build on !result.Passed. Errors are
+// returned only when the evaluation itself could not be made.
+func (s *ScanOperations) EvaluatePolicy(ctx context.Context, scanID, policyID string) (*PolicyResult, error) {
+	resp, err := s.client.makeRequest(ctx, http.MethodGet, "/scans/"+scanID+"/policy/"+policyID, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	var result PolicyResult
+	if err := decodeMap(resp, &result); err != nil {
+		return nil, err
+	}
+	if result.PolicyID == "" {
+		result.PolicyID = policyID
+	}
+	return &result, nil
+}
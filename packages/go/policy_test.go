@@ -0,0 +1,53 @@
+package tavo
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestEvaluatePolicy(t *testing.T) {
+	c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/scans/s1/policy/strict":
+			writeJSON(w, http.StatusOK, map[string]interface{}{
+				"passed": false,
+				"violations": []interface{}{map[string]interface{}{
+					"rule_id": "no-secrets", "severity": "critical", "message": "too many secrets",
+					"threshold": 0, "actual": 2, "finding_ids": []string{"f1", "f2"},
+				}},
+				"summary": map[string]interface{}{"total_findings": 5, "by_severity": map[string]int{"critical": 2, "low": 3}},
+			})
+		case "/scans/s1/policy/lenient":
+			writeJSON(w, http.StatusOK, map[string]interface{}{"policy_id": "lenient", "passed": true, "violations": []interface{}{}})
+		default:
+			writeJSON(w, http.StatusNotFound, map[string]interface{}{"message": "no such policy"})
+		}
+	})
+	ctx := context.Background()
+
+	res, err := c.Scans().EvaluatePolicy(ctx, "s1", "strict")
+	if err != nil {
+		t.Fatalf("failing policy returned error: %v", err)
+	}
+	if res.Passed || res.PolicyID != "strict" || len(res.Violations) != 1 {
+		t.Fatalf("result = %+v", res)
+	}
+	v := res.Violations[0]
+	if v.RuleID != "no-secrets" || v.Actual != 2 || len(v.FindingIDs) != 2 {
+		t.Fatalf("violation = %+v", v)
+	}
+	if res.Summary.TotalFindings != 5 || res.Summary.BySeverity["critical"] != 2 {
+		t.Fatalf("summary = %+v", res.Summary)
+	}
+
+	res, err = c.Scans().EvaluatePolicy(ctx, "s1", "lenient")
+	if err != nil || !res.Passed || len(res.Violations) != 0 {
+		t.Fatalf("lenient = %+v, %v", res, err)
+	}
+
+	if _, err := c.Scans().EvaluatePolicy(ctx, "s1", "missing"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("missing policy err = %v", err)
+	}
+}
@@ -0,0 +1,73 @@
+package tavo
+
+import (
+	"errors"
+	"net/http"
+)
+
+// DefaultPoolMaxIdleConnsPerHost is the number of idle connections to the
+// API host kept open by a ClientPool's shared transport.
+const DefaultPoolMaxIdleConnsPerHost = 100
+
+// ClientPool hands out per-tenant clients that share one transport and
+// connection pool. Clients differ only in their credentials, so creating
+// one per request is cheap and connections to the API are reused across
+// tenants. A ClientPool is safe for concurrent use.
+type ClientPool struct {
+	base      Config
+	transport http.RoundTripper
+	idle      *http.Transport
+}
+
+// NewClientPool builds a pool whose clients use the settings of base. Any
+// credentials in base are ignored; each client gets its own.
+func NewClientPool(base *Config) (*ClientPool, error) {
+	if base == nil {
+		base = NewConfig()
+	}
+	if base.BaseURL == "" {
+		return nil, errors.New("tavo: base URL is required")
+	}
+	idle := http.DefaultTransport.(*http.Transport).Clone()
+	idle.MaxIdleConnsPerHost = DefaultPoolMaxIdleConnsPerHost
+	tunePool(idle, base)
+
+	p := &ClientPool{base: *base.Clone(), transport: idle, idle: idle}
+	p.base.APIKey = ""
+	p.base.JWTToken = ""
+	p.base.SessionToken = ""
+	p.base.TokenSource = nil
+	if base.RateLimit > 0 {
+		// One limiter for the whole pool, so the configured rate applies
+		// across tenants unless RateLimitKeyFunc splits it.
+		p.transport = newRateLimitTransport(idle, base)
+	}
+	return p, nil
+}
+
+// Client returns a client authenticating with apiKey.
+func (p *ClientPool) Client(apiKey string) (*Client, error) {
+	cfg := p.base.Clone()
+	cfg.APIKey = apiKey
+	return p.client(cfg)
+}
+
+// ClientWithJWT returns a client authenticating with a JWT bearer token.
+func (p *ClientPool) ClientWithJWT(token string) (*Client, error) {
+	cfg := p.base.Clone()
+	cfg.JWTToken = token
+	return p.client(cfg)
+}
+
+func (p *ClientPool) client(cfg *Config) (*Client, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return newClient(cfg, p.transport), nil
+}
+
+// CloseIdleConnections closes idle connections held by the shared
+// transport.
+func (p *ClientPool) CloseIdleConnections() {
+	p.idle.CloseIdleConnections()
+}
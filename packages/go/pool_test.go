@@ -0,0 +1,71 @@
+package tavo
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+func TestClientPoolSharesConnectionsAndIsolatesAuth(t *testing.T) {
+	var conns int32
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]interface{}{"key": r.Header.Get("X-API-Key")})
+	}))
+	srv.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			atomic.AddInt32(&conns, 1)
+		}
+	}
+	srv.Start()
+	defer srv.Close()
+
+	pool, err := NewClientPool(NewConfig().WithBaseURL(srv.URL).WithAPIKey("ignored"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer pool.CloseIdleConnections()
+
+	for _, key := range []string{"tenant-a", "tenant-b", "tenant-c", "tenant-a"} {
+		c, err := pool.Client(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp, err := c.makeRequest(context.Background(), http.MethodGet, "/whoami", nil, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp["key"] != key {
+			t.Fatalf("server saw key %v, want %s", resp["key"], key)
+		}
+	}
+	if n := atomic.LoadInt32(&conns); n != 1 {
+		t.Fatalf("opened %d connections, want 1 shared connection", n)
+	}
+}
+
+func TestClientPoolJWTAndValidation(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-API-Key") != "" || r.Header.Get("Authorization") != "Bearer tok" {
+			t.Errorf("headers = %v", r.Header)
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{})
+	}))
+	defer srv.Close()
+	pool, err := NewClientPool(NewConfig().WithBaseURL(srv.URL).WithAPIKey("base-key"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	jc, err := pool.ClientWithJWT("tok")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := jc.makeRequest(context.Background(), http.MethodGet, "/x", nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := pool.Client(""); err == nil {
+		t.Fatal("expected error for client without credentials")
+	}
+}
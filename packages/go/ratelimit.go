@@ -0,0 +1,77 @@
+package tavo
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// limiterSweepInterval is how often rateLimitTransport drops idle buckets.
+const limiterSweepInterval = time.Minute
+
+// rateLimitTransport delays requests until their bucket has a token.
+// Buckets are created lazily per key and shared by all goroutines. A
+// bucket that has refilled completely behaves exactly like a new one, so
+// such buckets are dropped periodically; otherwise a key func with many
+// distinct keys would grow the map without bound.
+type rateLimitTransport struct {
+	next    http.RoundTripper
+	limit   rate.Limit
+	burst   int
+	keyFunc func(*http.Request) string
+
+	mu        sync.Mutex
+	limiters  map[string]*rate.Limiter
+	lastSweep time.Time
+}
+
+func newRateLimitTransport(next http.RoundTripper, config *Config) *rateLimitTransport {
+	burst := config.RateLimitBurst
+	if burst < 1 {
+		burst = 1
+	}
+	return &rateLimitTransport{
+		next:     next,
+		limit:    rate.Limit(config.RateLimit),
+		burst:    burst,
+		keyFunc:  config.RateLimitKeyFunc,
+		limiters: make(map[string]*rate.Limiter),
+	}
+}
+
+func (t *rateLimitTransport) limiter(key string) *rate.Limiter {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if now := time.Now(); now.Sub(t.lastSweep) >= limiterSweepInterval {
+		t.sweep(now)
+	}
+	l, ok := t.limiters[key]
+	if !ok {
+		l = rate.NewLimiter(t.limit, t.burst)
+		t.limiters[key] = l
+	}
+	return l
+}
+
+// sweep drops the buckets that are full at now. t.mu must be held.
+func (t *rateLimitTransport) sweep(now time.Time) {
+	for key, l := range t.limiters {
+		if l.TokensAt(now) >= float64(t.burst) {
+			delete(t.limiters, key)
+		}
+	}
+	t.lastSweep = now
+}
+
+func (t *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	key := ""
+	if t.keyFunc != nil {
+		key = t.keyFunc(req)
+	}
+	if err := t.limiter(key).Wait(req.Context()); err != nil {
+		return nil, err
+	}
+	return t.next.RoundTrip(req)
+}
@@ -0,0 +1,60 @@
+package tavo
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestRateLimitTransportKeysBuckets(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+
+	cfg := NewConfig().
+		WithRateLimit(1, 1).
+		WithRateLimitKeyFunc(func(r *http.Request) string { return r.Header.Get("X-Tenant") })
+	rt := newRateLimitTransport(http.DefaultTransport, cfg)
+
+	send := func(tenant string) time.Duration {
+		req, _ := http.NewRequest(http.MethodGet, srv.URL, nil)
+		req.Header.Set("X-Tenant", tenant)
+		start := time.Now()
+		resp, err := rt.RoundTrip(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		return time.Since(start)
+	}
+
+	// Each tenant's first request uses its own burst token immediately.
+	if d := send("a"); d > 200*time.Millisecond {
+		t.Fatalf("tenant a waited %s", d)
+	}
+	if d := send("b"); d > 200*time.Millisecond {
+		t.Fatalf("tenant b was throttled by tenant a: waited %s", d)
+	}
+	// A second request for the same tenant must wait for a refill.
+	if d := send("a"); d < 500*time.Millisecond {
+		t.Fatalf("tenant a was not throttled: waited %s", d)
+	}
+}
+
+func TestRateLimitTransportDropsIdleBuckets(t *testing.T) {
+	rt := newRateLimitTransport(http.DefaultTransport, NewConfig().WithRateLimit(1, 1))
+	rt.limiter("idle")
+	if !rt.limiter("busy").Allow() {
+		t.Fatal("first token refused")
+	}
+
+	rt.lastSweep = time.Time{}
+	rt.limiter("new")
+	if _, ok := rt.limiters["idle"]; ok || len(rt.limiters) != 2 {
+		t.Fatalf("buckets after sweep = %v", rt.limiters)
+	}
+	// The drained bucket must survive, or its key would get a fresh burst.
+	if rt.limiter("busy").Allow() {
+		t.Fatal("busy bucket was reset by the sweep")
+	}
+}
@@ -0,0 +1,156 @@
+package tavo
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ErrNoRecording is returned by ReplayTransport for a request that has no
+// recording.
+var ErrNoRecording = errors.New("tavo: no recording for request")
+
+// recording is one request/response pair as stored on disk. Bodies are
+// base64-encoded by encoding/json so binary downloads survive.
+type recording struct {
+	Method         string      `json:"method"`
+	URL            string      `json:"url"`
+	RequestHeader  http.Header `json:"request_header"`
+	RequestBody    []byte      `json:"request_body,omitempty"`
+	StatusCode     int         `json:"status_code"`
+	ResponseHeader http.Header `json:"response_header"`
+	ResponseBody   []byte      `json:"response_body,omitempty"`
+}
+
+// RecordingTransport sends requests through Next (http.DefaultTransport
+// when nil) and writes each exchange to a JSON file in Dir, named after the
+// method, path and a hash of the method, path, query and body. Credential
+// headers are redacted before writing. Replay the files with
+// ReplayTransport. Enable it on a client with Config.WithRecording.
+type RecordingTransport struct {
+	Dir  string
+	Next http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *RecordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	reqBody, err := drainBody(&req.Body)
+	if err != nil {
+		return nil, err
+	}
+	next := t.Next
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	resp, err := next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := drainBody(&resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	rec := recording{
+		Method:         req.Method,
+		URL:            req.URL.RequestURI(),
+		RequestHeader:  req.Header.Clone(),
+		RequestBody:    reqBody,
+		StatusCode:     resp.StatusCode,
+		ResponseHeader: resp.Header.Clone(),
+		ResponseBody:   respBody,
+	}
+	redactHeader(rec.RequestHeader)
+	redactHeader(rec.ResponseHeader)
+	data, err := json.MarshalIndent(rec, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(t.Dir, 0o755); err != nil {
+		return nil, fmt.Errorf("tavo: recording: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(t.Dir, recordingName(req.Method, req.URL.RequestURI(), reqBody)), data, 0o644); err != nil {
+		return nil, fmt.Errorf("tavo: recording: %w", err)
+	}
+	return resp, nil
+}
+
+// ReplayTransport answers requests from the files a RecordingTransport
+// wrote to Dir, without network access. A request with no matching file
+// fails with an error wrapping ErrNoRecording. Use it with
+// Config.WithReplay.
+type ReplayTransport struct {
+	Dir string
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *ReplayTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := drainBody(&req.Body)
+	if err != nil {
+		return nil, err
+	}
+	name := recordingName(req.Method, req.URL.RequestURI(), body)
+	data, err := os.ReadFile(filepath.Join(t.Dir, name))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("%w: %s %s (%s)", ErrNoRecording, req.Method, req.URL.RequestURI(), name)
+	}
+	if err != nil {
+		return nil, err
+	}
+	var rec recording
+	if err := json.Unmarshal(data, &rec); err != nil {
+		return nil, fmt.Errorf("tavo: reading recording %s: %w", name, err)
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", rec.StatusCode, http.StatusText(rec.StatusCode)),
+		StatusCode:    rec.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        rec.ResponseHeader,
+		Body:          io.NopCloser(bytes.NewReader(rec.ResponseBody)),
+		ContentLength: int64(len(rec.ResponseBody)),
+		Request:       req,
+	}, nil
+}
+
+// recordingName keys an exchange by method, path, query and body. The
+// readable prefix is for humans; the hash makes it unique.
+func recordingName(method, requestURI string, body []byte) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "%s\x00%s\x00", method, requestURI)
+	h.Write(body)
+	path, _, _ := strings.Cut(requestURI, "?")
+	slug := strings.Trim(strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' {
+			return r
+		}
+		return '_'
+	}, path), "_")
+	if len(slug) > 80 {
+		slug = slug[:80]
+	}
+	return fmt.Sprintf("%s_%s_%s.json", method, slug, hex.EncodeToString(h.Sum(nil))[:16])
+}
+
+// drainBody reads *body fully and replaces it with an in-memory copy.
+func drainBody(body *io.ReadCloser) ([]byte, error) {
+	if *body == nil || *body == http.NoBody {
+		return nil, nil
+	}
+	data, err := io.ReadAll(*body)
+	(*body).Close()
+	if err != nil {
+		return nil, err
+	}
+	*body = io.NopCloser(bytes.NewReader(data))
+	return data, nil
+}
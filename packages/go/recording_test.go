@@ -0,0 +1,82 @@
+package tavo
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRecordAndReplay(t *testing.T) {
+	dir := t.TempDir()
+	srv := httptest.NewServer(apiHandler(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Set-Cookie", "session=abc")
+		switch r.Method + " " + r.URL.Path {
+		case "GET /scans/s1":
+			writeJSON(w, http.StatusOK, map[string]interface{}{"id": "s1", "status": "completed"})
+		case "POST /scans":
+			writeJSON(w, http.StatusCreated, map[string]interface{}{"id": "s2"})
+		default:
+			writeJSON(w, http.StatusNotFound, map[string]interface{}{"message": "missing"})
+		}
+	}))
+	ctx := context.Background()
+
+	rec := newTestClientFor(t, srv, func(cfg *Config) { cfg.WithRecording(dir) })
+	if _, err := rec.Scans().GetScan(ctx, "s1"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := rec.Scans().CreateScan(ctx, map[string]interface{}{"name": "a"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := rec.Scans().GetScan(ctx, "gone"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("err = %v", err)
+	}
+	srv.Close()
+
+	files, _ := filepath.Glob(filepath.Join(dir, "*.json"))
+	if len(files) != 3 {
+		t.Fatalf("recorded %d files, want 3", len(files))
+	}
+	for _, f := range files {
+		data, _ := os.ReadFile(f)
+		if strings.Contains(string(data), "test-key") || strings.Contains(string(data), "session=abc") {
+			t.Fatalf("%s contains credentials:\n%s", f, data)
+		}
+	}
+
+	// The server is gone: everything below is served from the recordings.
+	replay := newTestClientFor(t, srv, func(cfg *Config) { cfg.WithReplay(dir).WithMaxRetries(0) })
+	scan, err := replay.Scans().GetScan(ctx, "s1")
+	if err != nil || scan["status"] != "completed" {
+		t.Fatalf("replayed scan = %v, %v", scan, err)
+	}
+	created, err := replay.Scans().CreateScan(ctx, map[string]interface{}{"name": "a"})
+	if err != nil || created["id"] != "s2" {
+		t.Fatalf("replayed create = %v, %v", created, err)
+	}
+	if _, err := replay.Scans().GetScan(ctx, "gone"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("replayed 404 err = %v", err)
+	}
+	// A different body is a different recording.
+	if _, err := replay.Scans().CreateScan(ctx, map[string]interface{}{"name": "b"}); !errors.Is(err, ErrNoRecording) {
+		t.Fatalf("unrecorded request err = %v", err)
+	}
+}
+
+func TestRecordingName(t *testing.T) {
+	a := recordingName("GET", "/api/v1/scans?limit=10", nil)
+	if !strings.HasPrefix(a, "GET_api_v1_scans_") || !strings.HasSuffix(a, ".json") {
+		t.Fatalf("name = %q", a)
+	}
+	if a == recordingName("GET", "/api/v1/scans?limit=20", nil) {
+		t.Fatal("query not part of the key")
+	}
+	if recordingName("POST", "/x", []byte("1")) == recordingName("POST", "/x", []byte("2")) {
+		t.Fatal("body not part of the key")
+	}
+}
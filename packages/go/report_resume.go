@@ -0,0 +1,102 @@
+package tavo
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// DownloadReportResumable downloads a report's file to destPath, resuming
+// an interrupted download. If destPath already holds part of the file, only
+// the remaining bytes are requested with a Range header and appended.
+//
+// The download starts over, truncating destPath, when the server ignores
+// the range (anything but 206 Partial Content with a matching
+// Content-Range) or rejects it with 416, for instance because the report
+// was regenerated. A fresh download is checked against X-Content-SHA256
+// like DownloadReportTo, and destPath is removed on a mismatch. After a
+// failed transfer destPath keeps what was received, so calling again
+// resumes from there.
+func (r *ReportOperations) DownloadReportResumable(ctx context.Context, reportID, destPath string) error {
+	path := "/reports/" + reportID + "/download"
+	var offset int64
+	if fi, err := os.Stat(destPath); err == nil {
+		offset = fi.Size()
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("tavo: %w", err)
+	}
+
+	headers := map[string]string{"Accept": "*/*"}
+	if offset > 0 {
+		headers["Range"] = fmt.Sprintf("bytes=%d-", offset)
+	}
+	resp, err := r.client.openRaw(ctx, path, nil, headers)
+	var te *TavoError
+	if offset > 0 && errors.As(err, &te) && te.StatusCode == http.StatusRequestedRangeNotSatisfiable {
+		offset = 0
+		delete(headers, "Range")
+		resp, err = r.client.openRaw(ctx, path, nil, headers)
+	}
+	if err != nil {
+		return err
+	}
+	body := resp.RawBody()
+	defer body.Close()
+
+	flags := os.O_CREATE | os.O_WRONLY | os.O_TRUNC
+	resumed := offset > 0 && resp.StatusCode() == http.StatusPartialContent &&
+		contentRangeStart(resp.Header().Get("Content-Range")) == offset
+	if resumed {
+		flags = os.O_WRONLY | os.O_APPEND
+	}
+	f, err := os.OpenFile(destPath, flags, 0o644)
+	if err != nil {
+		return fmt.Errorf("tavo: %w", err)
+	}
+
+	var w io.Writer = f
+	want := resp.Header().Get("X-Content-SHA256")
+	h := sha256.New()
+	if !resumed && want != "" {
+		w = io.MultiWriter(f, h)
+	}
+	_, copyErr := io.Copy(w, body)
+	if err := f.Close(); err != nil && copyErr == nil {
+		copyErr = err
+	}
+	if copyErr != nil {
+		return fmt.Errorf("tavo: downloading %s: %w", path, copyErr)
+	}
+	if !resumed && want != "" {
+		if err := verifySHA256(h.Sum(nil), want); err != nil {
+			os.Remove(destPath)
+			return fmt.Errorf("tavo: downloading %s: %w", path, err)
+		}
+	}
+	return nil
+}
+
+// contentRangeStart returns the first byte position of a Content-Range
+// header such as "bytes 100-199/200", or -1.
+func contentRangeStart(header string) int64 {
+	rest, ok := strings.CutPrefix(header, "bytes ")
+	if !ok {
+		return -1
+	}
+	start, _, ok := strings.Cut(rest, "-")
+	if !ok {
+		return -1
+	}
+	n, err := strconv.ParseInt(start, 10, 64)
+	if err != nil {
+		return -1
+	}
+	return n
+}
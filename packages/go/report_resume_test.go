@@ -0,0 +1,97 @@
+package tavo
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDownloadReportResumable(t *testing.T) {
+	content := []byte(strings.Repeat("0123456789", 100))
+	sum := sha256.Sum256(content)
+
+	for _, tc := range []struct {
+		name        string
+		partial     []byte
+		ignoreRange bool
+		wantRange   string
+	}{
+		{name: "fresh"},
+		{name: "resume", partial: content[:250], wantRange: "bytes=250-"},
+		{name: "server ignores range", partial: []byte("stale"), ignoreRange: true, wantRange: "bytes=5-"},
+		{name: "partial larger than report", partial: append(append([]byte{}, content...), "extra"...), wantRange: "bytes=1005-"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var ranges []string
+			c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/reports/r1/download" {
+					t.Errorf("path = %s", r.URL.Path)
+				}
+				ranges = append(ranges, r.Header.Get("Range"))
+				if tc.ignoreRange {
+					r.Header.Del("Range")
+				}
+				if r.Header.Get("Range") == "" {
+					w.Header().Set("X-Content-SHA256", hex.EncodeToString(sum[:]))
+				}
+				http.ServeContent(w, r, "report.pdf", time.Time{}, bytes.NewReader(content))
+			})
+			dest := filepath.Join(t.TempDir(), "report.pdf")
+			if tc.partial != nil {
+				if err := os.WriteFile(dest, tc.partial, 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			if err := c.Reports().DownloadReportResumable(context.Background(), "r1", dest); err != nil {
+				t.Fatal(err)
+			}
+			got, err := os.ReadFile(dest)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(got, content) {
+				t.Fatalf("file has %d bytes, want the %d-byte report", len(got), len(content))
+			}
+			if ranges[0] != tc.wantRange {
+				t.Fatalf("first Range = %q, want %q", ranges[0], tc.wantRange)
+			}
+		})
+	}
+}
+
+func TestDownloadReportResumableChecksumMismatch(t *testing.T) {
+	c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Content-SHA256", strings.Repeat("0", 64))
+		w.Write([]byte("corrupted"))
+	})
+	dest := filepath.Join(t.TempDir(), "report.pdf")
+	err := c.Reports().DownloadReportResumable(context.Background(), "r1", dest)
+	if !errors.Is(err, ErrChecksumMismatch) {
+		t.Fatalf("err = %v, want ErrChecksumMismatch", err)
+	}
+	if _, err := os.Stat(dest); !os.IsNotExist(err) {
+		t.Fatalf("corrupt file left behind: %v", err)
+	}
+}
+
+func TestContentRangeStart(t *testing.T) {
+	for in, want := range map[string]int64{
+		"bytes 100-199/200": 100,
+		"bytes 0-0/*":       0,
+		"bytes */200":       -1,
+		"":                  -1,
+	} {
+		if got := contentRangeStart(in); got != want {
+			t.Errorf("contentRangeStart(%q) = %d, want %d", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,217 @@
+package tavo
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// ReportOperations groups the /reports endpoints.
+type ReportOperations struct {
+	client *Client
+}
+
+// Report is a generated report.
+type Report struct {
+	ID          string    `json:"id"`
+	Type        string    `json:"type"`
+	Status      string    `json:"status"`
+	Format      string    `json:"format"`
+	CreatedAt   time.Time `json:"created_at"`
+	DownloadURL string    `json:"download_url,omitempty"`
+}
+
+// GenerateReport requests a new report. Generation is asynchronous; poll
+// GetReport until its status is "ready".
+func (r *ReportOperations) GenerateReport(ctx context.Context, params map[string]interface{}) (map[string]interface{}, error) {
+	return r.client.makeRequest(ctx, http.MethodPost, "/reports", params, nil)
+}
+
+// ReportFormats are the output formats accepted in the "format" parameter
+// of GenerateReport.
+var ReportFormats = []string{"pdf", "csv", "json", "sarif"}
+
+func validateReportFormat(params map[string]interface{}) error {
+	format, ok := params["format"]
+	if !ok {
+		return nil
+	}
+	for _, f := range ReportFormats {
+		if format == f {
+			return nil
+		}
+	}
+	return fmt.Errorf("tavo: unsupported report format %v (want one of %v)", format, ReportFormats)
+}
+
+// GenerateReportAndWait requests a report and polls it every pollInterval
+// (DefaultPollInterval when zero or negative) until its status is "ready"
+// or "failed". The "format" parameter, if set,
+// is checked against ReportFormats before anything is sent. A failed
+// report is returned together with an error.
+func (r *ReportOperations) GenerateReportAndWait(ctx context.Context, params map[string]interface{}, pollInterval time.Duration) (*Report, error) {
+	if err := validateReportFormat(params); err != nil {
+		return nil, err
+	}
+	resp, err := r.GenerateReport(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	var report Report
+	if err := decodeMap(resp, &report); err != nil {
+		return nil, err
+	}
+	if report.ID == "" {
+		return nil, fmt.Errorf("tavo: generate report response has no id")
+	}
+
+	if pollInterval <= 0 {
+		pollInterval = DefaultPollInterval
+	}
+	for {
+		switch report.Status {
+		case "ready":
+			return &report, nil
+		case "failed":
+			return &report, fmt.Errorf("tavo: report %s failed", report.ID)
+		}
+		if err := sleepContext(ctx, pollInterval); err != nil {
+			return nil, err
+		}
+		resp, err := r.GetReport(ctx, report.ID)
+		if err != nil {
+			return nil, err
+		}
+		report = Report{}
+		if err := decodeMap(resp, &report); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// GetReport fetches a report by ID.
+func (r *ReportOperations) GetReport(ctx context.Context, reportID string) (map[string]interface{}, error) {
+	return r.client.makeRequest(ctx, http.MethodGet, "/reports/"+reportID, nil, nil)
+}
+
+// ListReports lists reports.
+func (r *ReportOperations) ListReports(ctx context.Context, params map[string]interface{}) (map[string]interface{}, error) {
+	return r.client.makeRequest(ctx, http.MethodGet, "/reports", nil, params)
+}
+
+// DeleteReport deletes a report.
+func (r *ReportOperations) DeleteReport(ctx context.Context, reportID string) error {
+	_, err := r.client.makeRequest(ctx, http.MethodDelete, "/reports/"+reportID, nil, nil)
+	return err
+}
+
+// DownloadReportTo streams a generated report's file to w. If the server
+// sends an X-Content-SHA256 header, the download is verified against it
+// and ErrChecksumMismatch is returned on a mismatch.
+func (r *ReportOperations) DownloadReportTo(ctx context.Context, reportID string, w io.Writer) error {
+	return r.client.download(ctx, "/reports/"+reportID+"/download", "*/*", w)
+}
+
+// reportMediaTypes maps report formats to the media types requested when
+// downloading them.
+var reportMediaTypes = map[string]string{
+	"pdf":   "application/pdf",
+	"csv":   "text/csv",
+	"json":  "application/json",
+	"sarif": "application/sarif+json",
+}
+
+// DownloadReportFormat streams a report rendered in format, one of
+// ReportFormats, to w. It verifies checksums like DownloadReportTo.
+func (r *ReportOperations) DownloadReportFormat(ctx context.Context, reportID, format string, w io.Writer) error {
+	mediaType, ok := reportMediaTypes[format]
+	if !ok {
+		return fmt.Errorf("tavo: unsupported report format %q (want one of %v)", format, ReportFormats)
+	}
+	return r.client.download(ctx, "/reports/"+reportID+"/download", mediaType, w)
+}
+
+// ListReportsPage fetches one page of reports. nextOffset is the offset of
+// the following page, or -1 when this is the last page.
+func (r *ReportOperations) ListReportsPage(ctx context.Context, params map[string]interface{}) (reports []Report, nextOffset int, err error) {
+	offset, _ := toInt(params["offset"])
+	resp, err := r.ListReports(ctx, params)
+	if err != nil {
+		return nil, -1, err
+	}
+	items, total := pageItems(resp, offset)
+	reports = make([]Report, 0, len(items))
+	for _, item := range items {
+		var report Report
+		if err := decodeMap(item, &report); err != nil {
+			return nil, -1, err
+		}
+		reports = append(reports, report)
+	}
+
+	nextOffset = offset + len(items)
+	if len(items) == 0 || nextOffset >= total {
+		nextOffset = -1
+	}
+	return reports, nextOffset, nil
+}
+
+// IterateReports walks every report matching params.
+func (r *ReportOperations) IterateReports(ctx context.Context, params map[string]interface{}) *Iterator[Report] {
+	return newIterator(ctx, func(ctx context.Context, offset int) ([]Report, int, error) {
+		p := copyParams(params)
+		p["offset"] = offset
+		if _, ok := p["limit"]; !ok {
+			p["limit"] = DefaultPageSize
+		}
+		reports, next, err := r.ListReportsPage(ctx, p)
+		if err != nil {
+			return nil, 0, err
+		}
+		total := offset + len(reports)
+		if next >= 0 {
+			// More pages remain; let the iterator keep going.
+			total = next + 1
+		}
+		return reports, total, nil
+	})
+}
+
+// reportServerFields are set by the server and never sent when cloning.
+var reportServerFields = []string{"id", "status", "created_at", "updated_at", "completed_at", "download_url", "expires_at", "size"}
+
+// CloneReport generates a new report with the configuration of reportID,
+// replacing top-level settings with overrides (for example a new date
+// range). The configuration is read from the report's "config" object when
+// present, otherwise from the report itself minus server-managed fields.
+func (r *ReportOperations) CloneReport(ctx context.Context, reportID string, overrides map[string]interface{}) (*Report, error) {
+	source, err := r.GetReport(ctx, reportID)
+	if err != nil {
+		return nil, err
+	}
+
+	var params map[string]interface{}
+	if cfg, ok := source["config"].(map[string]interface{}); ok {
+		params = copyParams(cfg)
+	} else {
+		params = copyParams(source)
+		for _, field := range reportServerFields {
+			delete(params, field)
+		}
+	}
+	for k, v := range overrides {
+		params[k] = v
+	}
+
+	resp, err := r.GenerateReport(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	var report Report
+	if err := decodeMap(resp, &report); err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
@@ -0,0 +1,239 @@
+package tavo
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func reportsHandler(t *testing.T, total int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		var items []map[string]interface{}
+		for i := offset; i < total && i < offset+limit; i++ {
+			items = append(items, map[string]interface{}{
+				"id":         "r" + strconv.Itoa(i),
+				"type":       "compliance",
+				"status":     "ready",
+				"format":     "pdf",
+				"created_at": "2025-01-01T00:00:00Z",
+			})
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{"items": items, "total": total})
+	}
+}
+
+func TestListReportsPage(t *testing.T) {
+	c, _ := newTestClient(t, reportsHandler(t, 3))
+	ctx := context.Background()
+
+	reports, next, err := c.Reports().ListReportsPage(ctx, map[string]interface{}{"limit": 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(reports) != 2 || next != 2 || reports[0].Format != "pdf" || reports[0].CreatedAt.IsZero() {
+		t.Fatalf("reports = %+v, next = %d", reports, next)
+	}
+
+	reports, next, err = c.Reports().ListReportsPage(ctx, map[string]interface{}{"limit": 2, "offset": next})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(reports) != 1 || reports[0].ID != "r2" || next != -1 {
+		t.Fatalf("reports = %+v, next = %d", reports, next)
+	}
+}
+
+func TestIterateReports(t *testing.T) {
+	c, _ := newTestClient(t, reportsHandler(t, 5))
+
+	it := c.Reports().IterateReports(context.Background(), map[string]interface{}{"limit": 2})
+	var ids []string
+	for it.Next() {
+		ids = append(ids, it.Item().ID)
+	}
+	if err := it.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if len(ids) != 5 || ids[4] != "r4" {
+		t.Fatalf("ids = %v", ids)
+	}
+}
+
+func TestCloneReport(t *testing.T) {
+	c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/reports/r1":
+			writeJSON(w, http.StatusOK, map[string]interface{}{
+				"id":           "r1",
+				"status":       "ready",
+				"download_url": "https://files.test/r1.pdf",
+				"type":         "compliance",
+				"format":       "pdf",
+				"start_date":   "2025-01-01",
+				"end_date":     "2025-01-31",
+			})
+		case r.Method == http.MethodPost && r.URL.Path == "/reports":
+			var body map[string]interface{}
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Fatal(err)
+			}
+			for _, field := range []string{"id", "status", "download_url"} {
+				if _, ok := body[field]; ok {
+					t.Errorf("server field %q was sent", field)
+				}
+			}
+			if body["type"] != "compliance" || body["format"] != "pdf" {
+				t.Errorf("settings not copied: %v", body)
+			}
+			if body["start_date"] != "2025-02-01" || body["end_date"] != "2025-02-28" {
+				t.Errorf("overrides not applied: %v", body)
+			}
+			writeJSON(w, http.StatusAccepted, map[string]interface{}{"id": "r2", "status": "pending", "type": "compliance"})
+		default:
+			t.Errorf("unexpected %s %s", r.Method, r.URL.Path)
+		}
+	})
+
+	report, err := c.Reports().CloneReport(context.Background(), "r1", map[string]interface{}{
+		"start_date": "2025-02-01",
+		"end_date":   "2025-02-28",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.ID != "r2" || report.Status != "pending" {
+		t.Fatalf("report = %+v", report)
+	}
+}
+
+func TestGenerateReportAndWait(t *testing.T) {
+	polls := 0
+	c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/reports":
+			var body map[string]interface{}
+			json.NewDecoder(r.Body).Decode(&body)
+			if body["format"] != "sarif" {
+				t.Errorf("format = %v", body["format"])
+			}
+			writeJSON(w, http.StatusAccepted, map[string]interface{}{"id": "r1", "status": "pending", "format": "sarif"})
+		case r.Method == http.MethodGet && r.URL.Path == "/reports/r1":
+			polls++
+			if polls < 3 {
+				writeJSON(w, http.StatusOK, map[string]interface{}{"id": "r1", "status": "generating"})
+				return
+			}
+			writeJSON(w, http.StatusOK, map[string]interface{}{
+				"id": "r1", "status": "ready", "format": "sarif", "download_url": "https://files/r1.sarif",
+			})
+		default:
+			t.Errorf("unexpected %s %s", r.Method, r.URL.Path)
+		}
+	})
+
+	report, err := c.Reports().GenerateReportAndWait(context.Background(),
+		map[string]interface{}{"type": "security", "format": "sarif"}, time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if polls != 3 || report.Status != "ready" || report.DownloadURL != "https://files/r1.sarif" {
+		t.Fatalf("polls = %d, report = %+v", polls, report)
+	}
+}
+
+func TestGenerateReportAndWaitFailed(t *testing.T) {
+	c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]interface{}{"id": "r1", "status": "failed"})
+	})
+	report, err := c.Reports().GenerateReportAndWait(context.Background(), nil, time.Millisecond)
+	if err == nil || report == nil || report.Status != "failed" {
+		t.Fatalf("report = %+v, err = %v", report, err)
+	}
+}
+
+func TestGenerateReportAndWaitZeroInterval(t *testing.T) {
+	polls := 0
+	c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			polls++
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{"id": "r1", "status": "generating"})
+	})
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	_, err := c.Reports().GenerateReportAndWait(ctx, nil, 0)
+	if !errors.Is(err, context.DeadlineExceeded) || polls != 0 {
+		t.Fatalf("err = %v, polls = %d; want no poll before DefaultPollInterval", err, polls)
+	}
+}
+
+func TestGenerateReportAndWaitInvalidFormat(t *testing.T) {
+	c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Error("request sent for invalid format")
+	})
+	_, err := c.Reports().GenerateReportAndWait(context.Background(),
+		map[string]interface{}{"format": "docx"}, time.Millisecond)
+	if err == nil || !strings.Contains(err.Error(), "docx") {
+		t.Fatalf("err = %v", err)
+	}
+}
+
+func TestDownloadReportVerifiesChecksum(t *testing.T) {
+	const content = "report body"
+	sum := sha256.Sum256([]byte(content))
+	c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/reports/good/download":
+			w.Header().Set("X-Content-SHA256", hex.EncodeToString(sum[:]))
+			w.Write([]byte(content))
+		case "/reports/b64/download":
+			w.Header().Set("X-Content-SHA256", base64.StdEncoding.EncodeToString(sum[:]))
+			w.Write([]byte(content))
+		case "/reports/truncated/download":
+			w.Header().Set("X-Content-SHA256", hex.EncodeToString(sum[:]))
+			w.Write([]byte(content[:5]))
+		case "/reports/unsigned/download":
+			w.Write([]byte(content))
+		}
+	})
+	ctx := context.Background()
+
+	for _, id := range []string{"good", "b64", "unsigned"} {
+		var buf bytes.Buffer
+		if err := c.Reports().DownloadReportTo(ctx, id, &buf); err != nil || buf.String() != content {
+			t.Errorf("%s: body = %q, err = %v", id, buf.String(), err)
+		}
+	}
+	err := c.Reports().DownloadReportTo(ctx, "truncated", io.Discard)
+	if !errors.Is(err, ErrChecksumMismatch) {
+		t.Fatalf("err = %v, want ErrChecksumMismatch", err)
+	}
+}
+
+func TestDownloadReportFormat(t *testing.T) {
+	c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Accept"); got != "application/sarif+json" {
+			t.Errorf("Accept = %q", got)
+		}
+		w.Write([]byte("{}"))
+	})
+	var buf bytes.Buffer
+	if err := c.Reports().DownloadReportFormat(context.Background(), "r1", "sarif", &buf); err != nil || buf.String() != "{}" {
+		t.Fatalf("body = %q, err = %v", buf.String(), err)
+	}
+	if err := c.Reports().DownloadReportFormat(context.Background(), "r1", "docx", &buf); err == nil {
+		t.Fatal("expected error for unknown format")
+	}
+}
@@ -0,0 +1,57 @@
+package tavo
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"sync/atomic"
+)
+
+// requestIDHeader carries the server-assigned ID of a request.
+const requestIDHeader = "X-Request-ID"
+
+// requestIDTransport remembers the X-Request-ID of the latest response
+// from the API host. Responses from other hosts, such as presigned URLs
+// fetched with FetchURL, are ignored.
+type requestIDTransport struct {
+	next http.RoundTripper
+	host string
+	last *atomic.Value
+}
+
+func (t *requestIDTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.next.RoundTrip(req)
+	if err == nil && strings.EqualFold(req.URL.Host, t.host) {
+		if id := resp.Header.Get(requestIDHeader); id != "" {
+			t.last.Store(id)
+		}
+	}
+	return resp, err
+}
+
+// LastRequestID returns the X-Request-ID of the most recent API response
+// received by the client, or "" when the server has not sent one. Quote it
+// to Tavo support when reporting a problem. With concurrent requests it is
+// whichever response arrived last; prefer TavoError.RequestID for failed
+// calls.
+func (c *Client) LastRequestID() string {
+	id, _ := c.lastRequestID.Load().(string)
+	return id
+}
+
+// responseError builds the *TavoError for a non-2xx response, carrying
+// the response's request ID.
+func responseError(status int, header http.Header, body []byte) *TavoError {
+	e := newTavoError(status, body)
+	e.RequestID = header.Get(requestIDHeader)
+	return e
+}
+
+// apiHost returns the host of the configured BaseURL.
+func apiHost(config *Config) string {
+	u, err := url.Parse(config.BaseURL)
+	if err != nil {
+		return ""
+	}
+	return u.Host
+}
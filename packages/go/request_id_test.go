@@ -0,0 +1,81 @@
+package tavo
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRequestIDOnError(t *testing.T) {
+	c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/scans/ok" {
+			w.Header().Set("X-Request-ID", "req-ok")
+			writeJSON(w, http.StatusOK, map[string]interface{}{"id": "ok"})
+			return
+		}
+		w.Header().Set("X-Request-ID", "req-missing")
+		writeJSON(w, http.StatusNotFound, map[string]interface{}{"message": "scan not found"})
+	})
+	ctx := context.Background()
+
+	if c.LastRequestID() != "" {
+		t.Fatalf("LastRequestID before any request = %q", c.LastRequestID())
+	}
+	if _, err := c.Scans().GetScan(ctx, "ok"); err != nil {
+		t.Fatal(err)
+	}
+	if got := c.LastRequestID(); got != "req-ok" {
+		t.Fatalf("LastRequestID = %q, want req-ok", got)
+	}
+
+	_, err := c.Scans().GetScan(ctx, "missing")
+	var te *TavoError
+	if !errors.As(err, &te) {
+		t.Fatalf("err = %v, want *TavoError", err)
+	}
+	if te.RequestID != "req-missing" || c.LastRequestID() != "req-missing" {
+		t.Fatalf("RequestID = %q, LastRequestID = %q", te.RequestID, c.LastRequestID())
+	}
+	if want := "tavo: scan not found (status 404, request ID req-missing)"; err.Error() != want {
+		t.Fatalf("Error() = %q, want %q", err.Error(), want)
+	}
+}
+
+func TestRequestIDOnStreamError(t *testing.T) {
+	c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-ID", "req-dl")
+		writeJSON(w, http.StatusForbidden, map[string]interface{}{"message": "no access"})
+	})
+	err := c.download(context.Background(), "/reports/r1/download", "application/pdf", &bytes.Buffer{})
+	var te *TavoError
+	if !errors.As(err, &te) || te.RequestID != "req-dl" {
+		t.Fatalf("err = %v", err)
+	}
+}
+
+func TestRequestIDIgnoresForeignHosts(t *testing.T) {
+	foreign := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-ID", "foreign")
+		w.Write([]byte("report"))
+	}))
+	t.Cleanup(foreign.Close)
+	c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {})
+
+	if err := c.FetchURL(context.Background(), foreign.URL+"/r.pdf", &bytes.Buffer{}); err != nil {
+		t.Fatal(err)
+	}
+	if got := c.LastRequestID(); got != "" {
+		t.Fatalf("LastRequestID = %q after a foreign fetch", got)
+	}
+}
+
+func TestTavoErrorStringWithCodeAndRequestID(t *testing.T) {
+	e := &TavoError{StatusCode: 409, Code: "conflict", Message: "already exists", RequestID: "r1"}
+	if got := e.Error(); !strings.HasSuffix(got, "(status 409, code conflict, request ID r1)") {
+		t.Fatalf("Error() = %q", got)
+	}
+}
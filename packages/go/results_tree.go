@@ -0,0 +1,146 @@
+package tavo
+
+import (
+	"context"
+	"sort"
+	"strings"
+)
+
+// ResultTree organizes a scan's findings by directory and file.
+type ResultTree struct {
+	// Root is the directory containing every scanned path. Its Name and
+	// Path are empty.
+	Root *ResultNode
+}
+
+// ResultNode is a directory or file in a ResultTree.
+type ResultNode struct {
+	Name string
+	// Path is the slash-separated path from the root.
+	Path   string
+	IsFile bool
+	// Count is the number of findings in this file or anywhere below this
+	// directory; SeverityCounts breaks it down by severity.
+	Count          int
+	SeverityCounts map[string]int
+	// Children are sorted with directories first, then by name.
+	Children []*ResultNode
+	// Findings holds a file's findings in server order. It is nil for
+	// directories.
+	Findings []Finding
+
+	children map[string]*ResultNode
+}
+
+// Find returns the node at the slash-separated path p, or nil.
+func (t *ResultTree) Find(p string) *ResultNode {
+	node := t.Root
+	for _, part := range splitResultPath(p) {
+		node = node.children[part]
+		if node == nil {
+			return nil
+		}
+	}
+	return node
+}
+
+// GetResultsTree fetches every finding of a scan and arranges them into a
+// directory tree with per-node counts. The tree is built in a single pass
+// over the findings.
+func (s *ScanOperations) GetResultsTree(ctx context.Context, scanID string) (*ResultTree, error) {
+	findings, err := s.collectFindings(ctx, scanID)
+	if err != nil {
+		return nil, err
+	}
+	return buildResultTree(findings), nil
+}
+
+func buildResultTree(findings []Finding) *ResultTree {
+	root := newResultNode("", "", false)
+	for _, f := range findings {
+		parts := splitResultPath(f.File)
+		node := root
+		node.add(f)
+		for i, part := range parts {
+			child := node.children[part]
+			if child == nil {
+				child = newResultNode(part, strings.Join(parts[:i+1], "/"), i == len(parts)-1)
+				node.children[part] = child
+				node.Children = append(node.Children, child)
+			}
+			node = child
+			node.add(f)
+		}
+		node.Findings = append(node.Findings, f)
+	}
+	root.sortChildren()
+	return &ResultTree{Root: root}
+}
+
+func newResultNode(name, path string, isFile bool) *ResultNode {
+	return &ResultNode{
+		Name:           name,
+		Path:           path,
+		IsFile:         isFile,
+		SeverityCounts: make(map[string]int),
+		children:       make(map[string]*ResultNode),
+	}
+}
+
+func (n *ResultNode) add(f Finding) {
+	n.Count++
+	n.SeverityCounts[f.Severity]++
+}
+
+func (n *ResultNode) sortChildren() {
+	sort.Slice(n.Children, func(i, j int) bool {
+		a, b := n.Children[i], n.Children[j]
+		if a.IsFile != b.IsFile {
+			return !a.IsFile
+		}
+		return a.Name < b.Name
+	})
+	for _, c := range n.Children {
+		c.sortChildren()
+	}
+}
+
+func splitResultPath(p string) []string {
+	p = normalizeFingerprintPath(p)
+	var parts []string
+	for _, part := range strings.Split(p, "/") {
+		if part != "" && part != "." {
+			parts = append(parts, part)
+		}
+	}
+	return parts
+}
+
+// GetResultsByFile fetches every finding of a scan and groups the raw
+// findings by their "file" field, each file's findings sorted by line.
+// Paths are normalized to forward slashes without a leading "./", so
+// Windows and Unix paths to the same file share a group. Findings without
+// a file are grouped under "".
+func (s *ScanOperations) GetResultsByFile(ctx context.Context, scanID string) (map[string][]map[string]interface{}, error) {
+	list := func(ctx context.Context, params map[string]interface{}) (map[string]interface{}, error) {
+		return s.GetScanResults(ctx, scanID, params)
+	}
+	findings, err := FetchAll(ctx, listPages(ctx, list, nil))
+	if err != nil {
+		return nil, err
+	}
+	byFile := make(map[string][]map[string]interface{})
+	for _, f := range findings {
+		file, _ := f["file"].(string)
+		file = normalizeFingerprintPath(file)
+		byFile[file] = append(byFile[file], f)
+	}
+	for _, group := range byFile {
+		sort.SliceStable(group, func(i, j int) bool {
+			li, _ := toInt(group[i]["line"])
+			lj, _ := toInt(group[j]["line"])
+			return li < lj
+		})
+	}
+	return byFile, nil
+}
@@ -0,0 +1,83 @@
+package tavo
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"testing"
+)
+
+func TestGetResultsTree(t *testing.T) {
+	c, _ := newTestClient(t, findingsHandler(t, map[string][]map[string]interface{}{
+		"s1": {
+			{"id": "f1", "rule_id": "R1", "severity": "high", "file": "src/api/handler.go", "line": 3},
+			{"id": "f2", "rule_id": "R2", "severity": "low", "file": "src/api/handler.go", "line": 9},
+			{"id": "f3", "rule_id": "R1", "severity": "high", "file": "./src/main.go", "line": 1},
+			{"id": "f4", "rule_id": "R3", "severity": "medium", "file": "README.md", "line": 2},
+		},
+	}))
+
+	tree, err := c.Scans().GetResultsTree(context.Background(), "s1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tree.Root.Count != 4 || tree.Root.SeverityCounts["high"] != 2 {
+		t.Fatalf("root = %+v", tree.Root)
+	}
+	if names := []string{tree.Root.Children[0].Name, tree.Root.Children[1].Name}; names[0] != "src" || names[1] != "README.md" {
+		t.Fatalf("root children = %v", names)
+	}
+
+	src := tree.Find("src")
+	if src == nil || src.IsFile || src.Count != 3 || src.Children[0].Name != "api" {
+		t.Fatalf("src = %+v", src)
+	}
+	handler := tree.Find("src/api/handler.go")
+	if handler == nil || !handler.IsFile || handler.Count != 2 || len(handler.Findings) != 2 ||
+		handler.SeverityCounts["low"] != 1 || handler.Path != "src/api/handler.go" {
+		t.Fatalf("handler = %+v", handler)
+	}
+	if tree.Find("src/main.go").Count != 1 || tree.Find("nope") != nil {
+		t.Fatal("Find mismatch")
+	}
+}
+
+func TestGetResultsByFile(t *testing.T) {
+	findings := []map[string]interface{}{
+		{"id": "1", "file": "src/app.go", "line": 40},
+		{"id": "2", "file": "src\\app.go", "line": 3},
+		{"id": "3", "file": "./src/app.go", "line": 12},
+		{"id": "4", "file": "README.md", "line": 1},
+		{"id": "5", "line": 7},
+	}
+	c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/scans/s1/results" {
+			t.Errorf("path = %s", r.URL.Path)
+		}
+		// Serve two findings per page to exercise pagination.
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		end := offset + 2
+		if end > len(findings) {
+			end = len(findings)
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{"items": findings[offset:end], "total": len(findings)})
+	})
+
+	byFile, err := c.Scans().GetResultsByFile(context.Background(), "s1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(byFile) != 3 {
+		t.Fatalf("groups = %v", byFile)
+	}
+	var ids []string
+	for _, f := range byFile["src/app.go"] {
+		ids = append(ids, f["id"].(string))
+	}
+	if len(ids) != 3 || ids[0] != "2" || ids[1] != "3" || ids[2] != "1" {
+		t.Fatalf("src/app.go ids = %v, want [2 3 1]", ids)
+	}
+	if len(byFile["README.md"]) != 1 || len(byFile[""]) != 1 {
+		t.Fatalf("groups = %v", byFile)
+	}
+}
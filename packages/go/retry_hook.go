@@ -0,0 +1,43 @@
+package tavo
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// RetryHook is called before each retry with the retry's attempt number
+// (1 for the first retry), the request being retried, and the outcome of
+// the previous attempt: resp for a retryable status, err for a network
+// error.
+type RetryHook func(attempt int, req RequestInfo, resp *ResponseInfo, err error)
+
+// RequestInfo describes a request about to be retried.
+type RequestInfo struct {
+	Method string
+	// Path is the operation path, such as "/scans/123".
+	Path string
+	// Wait is the backoff before the retry is sent.
+	Wait time.Duration
+}
+
+// ResponseInfo describes the response that triggered a retry.
+type ResponseInfo struct {
+	StatusCode int
+	Header     http.Header
+}
+
+// notifyRetry calls the configured RetryHook, if any.
+func (c *Client) notifyRetry(attempt int, method, path string, wait time.Duration, resp *resty.Response, err error) {
+	if c.config.RetryHook == nil {
+		return
+	}
+	info := RequestInfo{Method: method, Path: path, Wait: wait}
+	var ri *ResponseInfo
+	if resp != nil {
+		ri = &ResponseInfo{StatusCode: resp.StatusCode(), Header: resp.Header()}
+		err = nil
+	}
+	c.config.RetryHook(attempt, info, ri, err)
+}
@@ -0,0 +1,82 @@
+package tavo
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestRetryHook(t *testing.T) {
+	type call struct {
+		attempt int
+		req     RequestInfo
+		status  int
+		err     error
+	}
+	var calls []call
+	n := 0
+	srv := httptest.NewServer(apiHandler(t, func(w http.ResponseWriter, r *http.Request) {
+		n++
+		switch n {
+		case 1:
+			w.Header().Set("Retry-After", "1")
+			writeJSON(w, http.StatusTooManyRequests, map[string]interface{}{"message": "slow down"})
+		case 2:
+			writeJSON(w, http.StatusBadGateway, map[string]interface{}{})
+		default:
+			writeJSON(w, http.StatusOK, map[string]interface{}{"id": "s1"})
+		}
+	}))
+	defer srv.Close()
+	c := newTestClientFor(t, srv, func(cfg *Config) {
+		cfg.WithRetryHook(func(attempt int, req RequestInfo, resp *ResponseInfo, err error) {
+			cl := call{attempt: attempt, req: req, err: err}
+			if resp != nil {
+				cl.status = resp.StatusCode
+				if attempt == 1 && resp.Header.Get("Retry-After") != "1" {
+					t.Errorf("headers = %v", resp.Header)
+				}
+			}
+			calls = append(calls, cl)
+		})
+	})
+
+	if _, err := c.Scans().GetScan(context.Background(), "s1"); err != nil {
+		t.Fatal(err)
+	}
+	want := []call{
+		{1, RequestInfo{http.MethodGet, "/scans/s1", time.Millisecond}, 429, nil},
+		{2, RequestInfo{http.MethodGet, "/scans/s1", 2 * time.Millisecond}, 502, nil},
+	}
+	if len(calls) != len(want) {
+		t.Fatalf("calls = %+v", calls)
+	}
+	for i := range want {
+		if calls[i] != want[i] {
+			t.Errorf("call %d = %+v, want %+v", i, calls[i], want[i])
+		}
+	}
+}
+
+func TestRetryHookNetworkError(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	srv.Close()
+
+	var gotErr error
+	var gotResp *ResponseInfo
+	hooks := 0
+	c := newTestClientFor(t, srv, func(cfg *Config) {
+		cfg.WithMaxRetries(1).WithRetryHook(func(attempt int, req RequestInfo, resp *ResponseInfo, err error) {
+			hooks++
+			gotResp, gotErr = resp, err
+		})
+	})
+	if _, err := c.Scans().GetScan(context.Background(), "s1"); err == nil {
+		t.Fatal("expected an error")
+	}
+	if hooks != 1 || gotResp != nil || gotErr == nil {
+		t.Fatalf("hooks = %d, resp = %v, err = %v", hooks, gotResp, gotErr)
+	}
+}
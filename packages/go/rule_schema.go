@@ -0,0 +1,315 @@
+package tavo
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// defaultRuleSchema is the JSON schema ValidateSchema checks rules against
+// unless Config.RuleSchema overrides it.
+//
+//go:embed schema/scan_rule.schema.json
+var defaultRuleSchema []byte
+
+// SchemaError lists the fields of a rule that do not match the rule
+// schema. Fields maps a field path, such as "severity" or "languages[2]",
+// to its messages; problems with the rule as a whole use the path "".
+type SchemaError struct {
+	Fields map[string][]string
+}
+
+// Error implements the error interface.
+func (e *SchemaError) Error() string {
+	paths := make([]string, 0, len(e.Fields))
+	for p := range e.Fields {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	var parts []string
+	for _, p := range paths {
+		for _, msg := range e.Fields[p] {
+			if p == "" {
+				parts = append(parts, msg)
+			} else {
+				parts = append(parts, p+": "+msg)
+			}
+		}
+	}
+	return "tavo: rule does not match schema: " + strings.Join(parts, "; ")
+}
+
+// jsonSchema is the subset of JSON Schema used by rule schemas: type,
+// required, properties, additionalProperties (as a boolean), items, enum,
+// pattern, minLength, maxLength, minimum, maximum, minItems and maxItems.
+// Annotations such as title and description are allowed; any other keyword
+// is rejected when the schema is compiled rather than silently ignored.
+type jsonSchema struct {
+	Type                 schemaTypes            `json:"type"`
+	Required             []string               `json:"required"`
+	Properties           map[string]*jsonSchema `json:"properties"`
+	AdditionalProperties *bool                  `json:"additionalProperties"`
+	Items                *jsonSchema            `json:"items"`
+	Enum                 []interface{}          `json:"enum"`
+	Pattern              string                 `json:"pattern"`
+	MinLength            *int                   `json:"minLength"`
+	MaxLength            *int                   `json:"maxLength"`
+	Minimum              *float64               `json:"minimum"`
+	Maximum              *float64               `json:"maximum"`
+	MinItems             *int                   `json:"minItems"`
+	MaxItems             *int                   `json:"maxItems"`
+
+	pattern *regexp.Regexp
+}
+
+// schemaTypes accepts "type" as a single name or a list of names.
+type schemaTypes []string
+
+func (t *schemaTypes) UnmarshalJSON(data []byte) error {
+	var one string
+	if json.Unmarshal(data, &one) == nil {
+		*t = schemaTypes{one}
+		return nil
+	}
+	var many []string
+	if err := json.Unmarshal(data, &many); err != nil {
+		return fmt.Errorf("type must be a string or a list of strings")
+	}
+	*t = many
+	return nil
+}
+
+// ValidateSchema checks a rule definition against the rule JSON schema
+// bundled with the SDK, or Config.RuleSchema when set, without sending
+// anything. A rule that does not match returns a *SchemaError listing the
+// offending fields. Passing does not guarantee the server will accept the
+// rule; use ValidateRule for a server-side check.
+func (r *ScanRuleOperations) ValidateSchema(ruleData map[string]interface{}) error {
+	r.schemaOnce.Do(func() {
+		data := r.client.config.RuleSchema
+		if len(data) == 0 {
+			data = defaultRuleSchema
+		}
+		r.schema, r.schemaErr = compileSchema(data)
+	})
+	if r.schemaErr != nil {
+		return r.schemaErr
+	}
+	// Round-trip through JSON so Go values such as ints and []string are
+	// checked the way the server will see them.
+	raw, err := json.Marshal(ruleData)
+	if err != nil {
+		return fmt.Errorf("tavo: encoding rule: %w", err)
+	}
+	var v interface{}
+	if err := json.Unmarshal(raw, &v); err != nil {
+		return fmt.Errorf("tavo: encoding rule: %w", err)
+	}
+	errs := make(map[string][]string)
+	r.schema.validate("", v, errs)
+	if len(errs) > 0 {
+		return &SchemaError{Fields: errs}
+	}
+	return nil
+}
+
+// schemaKeywords are the keywords a rule schema may use: those jsonSchema
+// implements plus annotations, which do not affect validation.
+var schemaKeywords = map[string]bool{
+	"type": true, "required": true, "properties": true, "additionalProperties": true,
+	"items": true, "enum": true, "pattern": true, "minLength": true, "maxLength": true,
+	"minimum": true, "maximum": true, "minItems": true, "maxItems": true,
+
+	"$schema": true, "$id": true, "$comment": true, "title": true,
+	"description": true, "default": true, "examples": true,
+}
+
+// compileSchema parses a JSON schema document.
+func compileSchema(data []byte) (*jsonSchema, error) {
+	var s jsonSchema
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("tavo: parsing rule schema: %w", err)
+	}
+	var raw interface{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("tavo: parsing rule schema: %w", err)
+	}
+	if err := checkSchemaKeywords("", raw); err != nil {
+		return nil, fmt.Errorf("tavo: parsing rule schema: %w", err)
+	}
+	if err := s.compile(); err != nil {
+		return nil, fmt.Errorf("tavo: parsing rule schema: %w", err)
+	}
+	return &s, nil
+}
+
+// checkSchemaKeywords reports the first keyword in the schema node v, at
+// path, or in its subschemas that is not in schemaKeywords.
+func checkSchemaKeywords(path string, v interface{}) error {
+	node, ok := v.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	keys := make([]string, 0, len(node))
+	for k := range node {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		if !schemaKeywords[k] {
+			if path == "" {
+				return fmt.Errorf("unsupported keyword %q", k)
+			}
+			return fmt.Errorf("unsupported keyword %q at %s", k, path)
+		}
+	}
+	if props, ok := node["properties"].(map[string]interface{}); ok {
+		names := make([]string, 0, len(props))
+		for name := range props {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			if err := checkSchemaKeywords(joinPath(path, "properties."+name), props[name]); err != nil {
+				return err
+			}
+		}
+	}
+	return checkSchemaKeywords(joinPath(path, "items"), node["items"])
+}
+
+func (s *jsonSchema) compile() error {
+	if s.Pattern != "" {
+		re, err := regexp.Compile(s.Pattern)
+		if err != nil {
+			return fmt.Errorf("pattern %q: %w", s.Pattern, err)
+		}
+		s.pattern = re
+	}
+	for _, p := range s.Properties {
+		if err := p.compile(); err != nil {
+			return err
+		}
+	}
+	if s.Items != nil {
+		return s.Items.compile()
+	}
+	return nil
+}
+
+// validate checks v, a value decoded from JSON, and records problems in
+// errs under path.
+func (s *jsonSchema) validate(path string, v interface{}, errs map[string][]string) {
+	add := func(format string, args ...interface{}) {
+		errs[path] = append(errs[path], fmt.Sprintf(format, args...))
+	}
+	if len(s.Type) > 0 && !s.Type.match(v) {
+		add("must be of type %s", strings.Join(s.Type, " or "))
+		return
+	}
+	if len(s.Enum) > 0 && !inEnum(s.Enum, v) {
+		add("must be one of %v", s.Enum)
+	}
+	switch v := v.(type) {
+	case string:
+		n := utf8.RuneCountInString(v)
+		if s.MinLength != nil && n < *s.MinLength {
+			add("must be at least %d characters", *s.MinLength)
+		}
+		if s.MaxLength != nil && n > *s.MaxLength {
+			add("must be at most %d characters", *s.MaxLength)
+		}
+		if s.pattern != nil && !s.pattern.MatchString(v) {
+			add("must match %s", s.Pattern)
+		}
+	case float64:
+		if s.Minimum != nil && v < *s.Minimum {
+			add("must be at least %v", *s.Minimum)
+		}
+		if s.Maximum != nil && v > *s.Maximum {
+			add("must be at most %v", *s.Maximum)
+		}
+	case []interface{}:
+		if s.MinItems != nil && len(v) < *s.MinItems {
+			add("must have at least %d items", *s.MinItems)
+		}
+		if s.MaxItems != nil && len(v) > *s.MaxItems {
+			add("must have at most %d items", *s.MaxItems)
+		}
+		if s.Items != nil {
+			for i, item := range v {
+				s.Items.validate(fmt.Sprintf("%s[%d]", path, i), item, errs)
+			}
+		}
+	case map[string]interface{}:
+		for _, name := range s.Required {
+			if _, ok := v[name]; !ok {
+				errs[joinPath(path, name)] = append(errs[joinPath(path, name)], "is required")
+			}
+		}
+		for name, val := range v {
+			if prop, ok := s.Properties[name]; ok {
+				prop.validate(joinPath(path, name), val, errs)
+			} else if s.AdditionalProperties != nil && !*s.AdditionalProperties {
+				errs[joinPath(path, name)] = append(errs[joinPath(path, name)], "is not allowed")
+			}
+		}
+	}
+}
+
+func (t schemaTypes) match(v interface{}) bool {
+	for _, name := range t {
+		switch name {
+		case "string":
+			if _, ok := v.(string); ok {
+				return true
+			}
+		case "number":
+			if _, ok := v.(float64); ok {
+				return true
+			}
+		case "integer":
+			if f, ok := v.(float64); ok && f == float64(int64(f)) {
+				return true
+			}
+		case "boolean":
+			if _, ok := v.(bool); ok {
+				return true
+			}
+		case "array":
+			if _, ok := v.([]interface{}); ok {
+				return true
+			}
+		case "object":
+			if _, ok := v.(map[string]interface{}); ok {
+				return true
+			}
+		case "null":
+			if v == nil {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func inEnum(enum []interface{}, v interface{}) bool {
+	for _, e := range enum {
+		if reflect.DeepEqual(e, v) {
+			return true
+		}
+	}
+	return false
+}
+
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
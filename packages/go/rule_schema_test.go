@@ -0,0 +1,100 @@
+package tavo
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestValidateSchema(t *testing.T) {
+	c, _ := newTestClient(t, nil)
+	rules := c.ScanRules()
+
+	valid := map[string]interface{}{
+		"slug": "no-eval", "name": "No eval", "severity": "high",
+		"languages": []string{"python", "javascript"}, "confidence": 0.9,
+	}
+	if err := rules.ValidateSchema(valid); err != nil {
+		t.Fatalf("valid rule: %v", err)
+	}
+
+	err := rules.ValidateSchema(map[string]interface{}{
+		"slug":       "No Eval",
+		"severity":   "urgent",
+		"enabled":    "yes",
+		"languages":  []interface{}{"go", ""},
+		"confidence": 2,
+	})
+	var se *SchemaError
+	if !errors.As(err, &se) {
+		t.Fatalf("err = %v, want *SchemaError", err)
+	}
+	want := map[string][]string{
+		"name":         {"is required"},
+		"slug":         {"must match ^[a-z0-9][a-z0-9-]*$"},
+		"severity":     {"must be one of [info low medium high critical]"},
+		"enabled":      {"must be of type boolean"},
+		"languages[1]": {"must be at least 1 characters"},
+		"confidence":   {"must be at most 1"},
+	}
+	if !reflect.DeepEqual(se.Fields, want) {
+		t.Fatalf("Fields = %v\nwant %v", se.Fields, want)
+	}
+	if !strings.HasPrefix(err.Error(), "tavo: rule does not match schema: confidence: must be at most 1; enabled:") {
+		t.Fatalf("Error() = %q", err.Error())
+	}
+}
+
+func TestWithRuleSchema(t *testing.T) {
+	schema := []byte(`{
+		"type": "object",
+		"required": ["id"],
+		"additionalProperties": false,
+		"properties": {
+			"id": {"type": "integer"},
+			"steps": {"type": "array", "minItems": 1, "items": {"type": "object", "required": ["match"]}}
+		}
+	}`)
+	srv := httptest.NewServer(http.NotFoundHandler())
+	t.Cleanup(srv.Close)
+	c := newTestClientFor(t, srv, func(cfg *Config) { cfg.WithRuleSchema(schema) })
+
+	if err := c.ScanRules().ValidateSchema(map[string]interface{}{"id": 3, "steps": []interface{}{map[string]interface{}{"match": "x"}}}); err != nil {
+		t.Fatalf("custom-valid rule: %v", err)
+	}
+	var se *SchemaError
+	err := c.ScanRules().ValidateSchema(map[string]interface{}{"id": 1.5, "name": "x", "steps": []interface{}{map[string]interface{}{}}})
+	if !errors.As(err, &se) {
+		t.Fatalf("err = %v", err)
+	}
+	want := map[string][]string{
+		"id":             {"must be of type integer"},
+		"name":           {"is not allowed"},
+		"steps[0].match": {"is required"},
+	}
+	if !reflect.DeepEqual(se.Fields, want) {
+		t.Fatalf("Fields = %v, want %v", se.Fields, want)
+	}
+}
+
+func TestValidateRejectsBadRuleSchema(t *testing.T) {
+	cfg := NewConfig().WithAPIKey("k").WithRuleSchema([]byte(`{"type":`))
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "rule schema") {
+		t.Fatalf("Validate() = %v", err)
+	}
+	cfg.WithRuleSchema([]byte(`{"properties":{"a":{"pattern":"("}}}`))
+	if err := cfg.Validate(); err == nil {
+		t.Fatal("schema with an invalid pattern accepted")
+	}
+	cfg.WithRuleSchema([]byte(`{"title":"t","properties":{"a":{"items":{"type":"string","format":"email"}}}}`))
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), `"format" at properties.a.items`) {
+		t.Fatalf("schema with an unsupported keyword: Validate() = %v", err)
+	}
+	cfg.WithRuleSchema([]byte(`{"oneOf":[{"type":"string"}]}`))
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), `"oneOf"`) {
+		t.Fatalf("schema with an unsupported keyword: Validate() = %v", err)
+	}
+}
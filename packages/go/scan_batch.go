@@ -0,0 +1,67 @@
+package tavo
+
+import (
+	"context"
+	"sync"
+)
+
+// DefaultBatchConcurrency is the number of parallel requests
+// GetManyScanResults makes when no concurrency is given.
+const DefaultBatchConcurrency = 8
+
+// GetManyScanResults fetches the results of many scans in parallel, with at
+// most concurrency requests in flight. It returns the results by scan ID
+// and, separately, the error for every scan that could not be fetched; each
+// ID appears in exactly one of the two maps. Once ctx is done no new
+// requests are started and the remaining scans report ctx.Err().
+func (s *ScanOperations) GetManyScanResults(ctx context.Context, scanIDs []string, concurrency int) (map[string]map[string]interface{}, map[string]error) {
+	if concurrency <= 0 {
+		concurrency = DefaultBatchConcurrency
+	}
+	results := make(map[string]map[string]interface{}, len(scanIDs))
+	errs := make(map[string]error)
+	var mu sync.Mutex
+	record := func(id string, res map[string]interface{}, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if err != nil {
+			errs[id] = err
+		} else {
+			results[id] = res
+		}
+	}
+
+	sem := make(chan struct{}, concurrency)
+	seen := make(map[string]bool, len(scanIDs))
+	var wg sync.WaitGroup
+	for _, id := range scanIDs {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		acquired := false
+		select {
+		case <-ctx.Done():
+		case sem <- struct{}{}:
+			acquired = true
+		}
+		// Both cases may be ready at once; cancellation wins.
+		if err := ctx.Err(); err != nil {
+			if acquired {
+				<-sem
+			}
+			record(id, nil, err)
+			continue
+		}
+		id := id
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			res, err := s.GetScanResults(ctx, id, nil)
+			record(id, res, err)
+		}()
+	}
+	wg.Wait()
+	return results, errs
+}
@@ -0,0 +1,79 @@
+package tavo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestGetManyScanResults(t *testing.T) {
+	var inFlight, peak int32
+	c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			p := atomic.LoadInt32(&peak)
+			if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+
+		id := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/scans/"), "/results")
+		if id == "missing" {
+			writeJSON(w, http.StatusNotFound, map[string]interface{}{"message": "no such scan"})
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{"scan_id": id})
+	})
+
+	ids := []string{"missing"}
+	for i := 0; i < 20; i++ {
+		ids = append(ids, fmt.Sprintf("s%d", i))
+	}
+	results, errs := c.Scans().GetManyScanResults(context.Background(), ids, 3)
+
+	if len(results) != 20 || results["s7"]["scan_id"] != "s7" {
+		t.Fatalf("results = %v", results)
+	}
+	if len(errs) != 1 || !errors.Is(errs["missing"], ErrNotFound) {
+		t.Fatalf("errs = %v", errs)
+	}
+	if p := atomic.LoadInt32(&peak); p > 3 {
+		t.Fatalf("peak concurrency = %d, want <= 3", p)
+	}
+}
+
+func TestGetManyScanResultsStopsOnCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	var mu sync.Mutex
+	requested := 0
+	c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requested++
+		mu.Unlock()
+		cancel()
+		writeJSON(w, http.StatusOK, map[string]interface{}{})
+	})
+
+	ids := []string{"a", "b", "c", "d", "e"}
+	results, errs := c.Scans().GetManyScanResults(ctx, ids, 1)
+
+	if len(results)+len(errs) != len(ids) {
+		t.Fatalf("%d results and %d errors for %d ids", len(results), len(errs), len(ids))
+	}
+	if !errors.Is(errs["e"], context.Canceled) {
+		t.Fatalf("errs[e] = %v, want context.Canceled", errs["e"])
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if requested > 2 {
+		t.Fatalf("%d requests sent after cancellation", requested)
+	}
+}
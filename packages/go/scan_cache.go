@@ -0,0 +1,58 @@
+package tavo
+
+import (
+	"context"
+	"net/http"
+)
+
+type cachedScan struct {
+	etag string
+	scan map[string]interface{}
+}
+
+// GetScanCached fetches a scan like GetScan but revalidates a cached copy
+// with If-None-Match. When the server answers 304 Not Modified the cached
+// scan is returned without transferring it again. The returned map is
+// shared with the cache and must not be modified.
+func (s *ScanOperations) GetScanCached(ctx context.Context, scanID string) (map[string]interface{}, error) {
+	s.cacheMu.Lock()
+	entry, ok := s.cache[scanID]
+	s.cacheMu.Unlock()
+
+	req := &apiRequest{method: http.MethodGet, path: "/scans/" + scanID}
+	if ok {
+		req.headers = map[string]string{"If-None-Match": entry.etag}
+	}
+	resp, err := s.client.execute(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if ok && resp.StatusCode() == http.StatusNotModified {
+		return entry.scan, nil
+	}
+
+	scan, err := checkResponse(resp)
+	if err != nil {
+		if resp.StatusCode() == http.StatusNotFound {
+			s.forgetScan(scanID)
+		}
+		return nil, err
+	}
+	if etag := resp.Header().Get("ETag"); etag != "" {
+		s.cacheMu.Lock()
+		if s.cache == nil {
+			s.cache = make(map[string]cachedScan)
+		}
+		s.cache[scanID] = cachedScan{etag: etag, scan: scan}
+		s.cacheMu.Unlock()
+	} else {
+		s.forgetScan(scanID)
+	}
+	return scan, nil
+}
+
+func (s *ScanOperations) forgetScan(scanID string) {
+	s.cacheMu.Lock()
+	delete(s.cache, scanID)
+	s.cacheMu.Unlock()
+}
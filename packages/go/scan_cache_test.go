@@ -0,0 +1,51 @@
+package tavo
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"testing"
+)
+
+func TestGetScanCachedRevalidatesWithETag(t *testing.T) {
+	var version int32 = 1
+	var fullResponses int32
+	c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		etag := `"v1"`
+		if atomic.LoadInt32(&version) == 2 {
+			etag = `"v2"`
+		}
+		if r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		atomic.AddInt32(&fullResponses, 1)
+		w.Header().Set("ETag", etag)
+		writeJSON(w, http.StatusOK, map[string]interface{}{"id": "s1", "etag": etag})
+	})
+	ctx := context.Background()
+
+	first, err := c.Scans().GetScanCached(ctx, "s1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := c.Scans().GetScanCached(ctx, "s1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if second["etag"] != `"v1"` || fullResponses != 1 {
+		t.Fatalf("second = %v, full responses = %d", second, fullResponses)
+	}
+	if first["id"] != "s1" {
+		t.Fatalf("first = %v", first)
+	}
+
+	atomic.StoreInt32(&version, 2)
+	third, err := c.Scans().GetScanCached(ctx, "s1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if third["etag"] != `"v2"` || fullResponses != 2 {
+		t.Fatalf("third = %v, full responses = %d", third, fullResponses)
+	}
+}
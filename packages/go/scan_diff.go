@@ -0,0 +1,103 @@
+package tavo
+
+import (
+	"context"
+	"fmt"
+)
+
+// ScanDiff categorizes the findings of a scan against a baseline scan.
+type ScanDiff struct {
+	// Added holds findings present in the current scan but not the baseline.
+	Added []Finding
+	// Removed holds baseline findings missing from the current scan.
+	Removed []Finding
+	// Unchanged holds current-scan findings that also appear in the baseline.
+	Unchanged []Finding
+}
+
+// CompareOption customizes CompareScans.
+type CompareOption func(*compareOptions)
+
+type compareOptions struct {
+	ignoreLines   bool
+	byFingerprint bool
+}
+
+// IgnoreLineNumbers matches findings by rule ID and file only, so findings
+// that merely moved within a file are reported as unchanged.
+func IgnoreLineNumbers() CompareOption {
+	return func(o *compareOptions) { o.ignoreLines = true }
+}
+
+// MatchByFingerprint matches findings by Finding.Fingerprint, so a
+// finding whose code moved to another line is unchanged while a different
+// occurrence of the same rule in the same file is not.
+func MatchByFingerprint() CompareOption {
+	return func(o *compareOptions) { o.byFingerprint = true }
+}
+
+// CompareScans fetches the findings of both scans and reports which are new,
+// fixed or still present in currentID relative to baselineID. Findings are
+// matched by rule ID, file and line; repeated matches are paired one to one.
+func (s *ScanOperations) CompareScans(ctx context.Context, baselineID, currentID string, opts ...CompareOption) (*ScanDiff, error) {
+	var o compareOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	baseline, err := s.collectFindings(ctx, baselineID)
+	if err != nil {
+		return nil, fmt.Errorf("tavo: fetching baseline scan %s: %w", baselineID, err)
+	}
+	current, err := s.collectFindings(ctx, currentID)
+	if err != nil {
+		return nil, fmt.Errorf("tavo: fetching scan %s: %w", currentID, err)
+	}
+	return diffFindings(baseline, current, o), nil
+}
+
+func (s *ScanOperations) collectFindings(ctx context.Context, scanID string) ([]Finding, error) {
+	var findings []Finding
+	it := s.IterateFindings(ctx, scanID, ResultFilter{})
+	for it.Next() {
+		findings = append(findings, it.Item())
+	}
+	return findings, it.Err()
+}
+
+func diffFindings(baseline, current []Finding, o compareOptions) *ScanDiff {
+	key := func(f Finding) string {
+		if o.byFingerprint {
+			return f.Fingerprint()
+		}
+		if o.ignoreLines {
+			return f.RuleID + "\x00" + f.File
+		}
+		return fmt.Sprintf("%s\x00%s\x00%d", f.RuleID, f.File, f.Line)
+	}
+
+	pending := make(map[string][]int, len(baseline))
+	for i, f := range baseline {
+		k := key(f)
+		pending[k] = append(pending[k], i)
+	}
+
+	diff := &ScanDiff{Added: []Finding{}, Removed: []Finding{}, Unchanged: []Finding{}}
+	matched := make([]bool, len(baseline))
+	for _, f := range current {
+		k := key(f)
+		if idx := pending[k]; len(idx) > 0 {
+			matched[idx[0]] = true
+			pending[k] = idx[1:]
+			diff.Unchanged = append(diff.Unchanged, f)
+			continue
+		}
+		diff.Added = append(diff.Added, f)
+	}
+	for i, f := range baseline {
+		if !matched[i] {
+			diff.Removed = append(diff.Removed, f)
+		}
+	}
+	return diff
+}
@@ -0,0 +1,91 @@
+package tavo
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// diffCollapseThreshold is the size above which a severity group is
+// rendered inside a collapsed <details> section.
+const diffCollapseThreshold = 10
+
+// severityEmoji marks severities in FormatDiffMarkdown output.
+var severityEmoji = map[Severity]string{
+	SeverityCritical: "🔴",
+	SeverityHigh:     "🟠",
+	SeverityMedium:   "🟡",
+	SeverityLow:      "🔵",
+	SeverityInfo:     "⚪",
+	SeverityUnknown:  "⚫",
+}
+
+// FormatDiffMarkdown renders the added and removed findings of diff as
+// Markdown suitable for a pull request comment: a summary line, then a
+// table per severity, most severe first, marked with an emoji. Groups of
+// more than 10 findings are collapsed into a <details> section, and
+// findings of an unrecognized severity are grouped as "Unknown". Unchanged
+// findings are only counted.
+func FormatDiffMarkdown(diff *ScanDiff) string {
+	var b strings.Builder
+	b.WriteString("## Tavo scan comparison\n\n")
+	fmt.Fprintf(&b, "**%d added**, **%d removed**, %d unchanged\n", len(diff.Added), len(diff.Removed), len(diff.Unchanged))
+	if len(diff.Added) == 0 && len(diff.Removed) == 0 {
+		b.WriteString("\nNo new or removed findings.\n")
+		return b.String()
+	}
+	writeDiffSection(&b, "Added findings", diff.Added)
+	writeDiffSection(&b, "Removed findings", diff.Removed)
+	return b.String()
+}
+
+func writeDiffSection(b *strings.Builder, title string, findings []Finding) {
+	if len(findings) == 0 {
+		return
+	}
+	fmt.Fprintf(b, "\n### %s\n", title)
+
+	groups := make(map[Severity][]Finding)
+	for _, f := range findings {
+		sev := f.SeverityLevel()
+		groups[sev] = append(groups[sev], f)
+	}
+	severities := make([]Severity, 0, len(groups))
+	for sev := range groups {
+		severities = append(severities, sev)
+	}
+	sort.Slice(severities, func(i, j int) bool { return severities[i] > severities[j] })
+
+	for _, sev := range severities {
+		group := groups[sev]
+		name := sev.String()
+		heading := fmt.Sprintf("%s %s (%d)", severityEmoji[sev], strings.ToUpper(name[:1])+name[1:], len(group))
+		collapse := len(group) > diffCollapseThreshold
+		if collapse {
+			fmt.Fprintf(b, "\n<details>\n<summary>%s</summary>\n", heading)
+		} else {
+			fmt.Fprintf(b, "\n#### %s\n", heading)
+		}
+		b.WriteString("\n| Rule | Location | Message |\n|---|---|---|\n")
+		for _, f := range group {
+			fmt.Fprintf(b, "| `%s` | `%s` | %s |\n", f.RuleID, findingLocation(f), markdownCell(f.Message))
+		}
+		if collapse {
+			b.WriteString("\n</details>\n")
+		}
+	}
+}
+
+func findingLocation(f Finding) string {
+	if f.Line > 0 {
+		return fmt.Sprintf("%s:%d", f.File, f.Line)
+	}
+	return f.File
+}
+
+// markdownCell keeps text on one table row: pipes are escaped and line
+// breaks become spaces.
+func markdownCell(s string) string {
+	s = strings.ReplaceAll(s, "|", `\|`)
+	return strings.Join(strings.Fields(s), " ")
+}
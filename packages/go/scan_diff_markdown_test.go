@@ -0,0 +1,56 @@
+package tavo
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestFormatDiffMarkdown(t *testing.T) {
+	diff := &ScanDiff{
+		Added: []Finding{
+			{RuleID: "weak-hash", Severity: "medium", File: "auth.go", Line: 10, Message: "md5 | sha1\nused"},
+			{RuleID: "sqli", Severity: "critical", File: "db.go", Line: 42, Message: "SQL injection"},
+		},
+		Removed:   []Finding{{RuleID: "xss", Severity: "high", File: "web/view.go", Line: 7, Message: "XSS"}},
+		Unchanged: make([]Finding, 4),
+	}
+	got := FormatDiffMarkdown(diff)
+	want := "## Tavo scan comparison\n\n" +
+		"**2 added**, **1 removed**, 4 unchanged\n" +
+		"\n### Added findings\n" +
+		"\n#### 🔴 Critical (1)\n" +
+		"\n| Rule | Location | Message |\n|---|---|---|\n" +
+		"| `sqli` | `db.go:42` | SQL injection |\n" +
+		"\n#### 🟡 Medium (1)\n" +
+		"\n| Rule | Location | Message |\n|---|---|---|\n" +
+		"| `weak-hash` | `auth.go:10` | md5 \\| sha1 used |\n" +
+		"\n### Removed findings\n" +
+		"\n#### 🟠 High (1)\n" +
+		"\n| Rule | Location | Message |\n|---|---|---|\n" +
+		"| `xss` | `web/view.go:7` | XSS |\n"
+	if got != want {
+		t.Fatalf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestFormatDiffMarkdownCollapsesLargeGroups(t *testing.T) {
+	diff := &ScanDiff{}
+	for i := 0; i < diffCollapseThreshold+1; i++ {
+		diff.Added = append(diff.Added, Finding{RuleID: fmt.Sprintf("r%d", i), Severity: "low", File: "a.go", Line: i + 1})
+	}
+	got := FormatDiffMarkdown(diff)
+	if !strings.Contains(got, "<details>\n<summary>🔵 Low (11)</summary>\n") || !strings.Contains(got, "</details>") {
+		t.Fatalf("large group not collapsed:\n%s", got)
+	}
+	if strings.Contains(got, "#### 🔵") {
+		t.Fatalf("collapsed group also has a heading:\n%s", got)
+	}
+}
+
+func TestFormatDiffMarkdownNoChanges(t *testing.T) {
+	got := FormatDiffMarkdown(&ScanDiff{Unchanged: make([]Finding, 2)})
+	if !strings.HasSuffix(got, "**0 added**, **0 removed**, 2 unchanged\n\nNo new or removed findings.\n") {
+		t.Fatalf("got %q", got)
+	}
+}
@@ -0,0 +1,67 @@
+package tavo
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func findingsHandler(t *testing.T, results map[string][]map[string]interface{}) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/scans/"), "/results")
+		items, ok := results[id]
+		if !ok {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		if off := r.URL.Query().Get("offset"); off != "" && off != "0" {
+			items = nil
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{"items": items, "total": len(results[id])})
+	}
+}
+
+func finding(rule, file string, line int) map[string]interface{} {
+	return map[string]interface{}{"rule_id": rule, "file": file, "line": line}
+}
+
+func TestCompareScans(t *testing.T) {
+	c, _ := newTestClient(t, findingsHandler(t, map[string][]map[string]interface{}{
+		"base": {finding("sqli", "a.go", 10), finding("xss", "b.go", 5), finding("xss", "b.go", 5)},
+		"head": {finding("sqli", "a.go", 10), finding("xss", "b.go", 5), finding("secret", "c.go", 1)},
+	}))
+
+	diff, err := c.Scans().CompareScans(context.Background(), "base", "head")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(diff.Unchanged) != 2 || len(diff.Added) != 1 || len(diff.Removed) != 1 {
+		t.Fatalf("diff = %+v", diff)
+	}
+	if diff.Added[0].RuleID != "secret" || diff.Removed[0].RuleID != "xss" {
+		t.Fatalf("diff = %+v", diff)
+	}
+}
+
+func TestCompareScansIgnoreLineNumbers(t *testing.T) {
+	c, _ := newTestClient(t, findingsHandler(t, map[string][]map[string]interface{}{
+		"base": {finding("sqli", "a.go", 10)},
+		"head": {finding("sqli", "a.go", 14)},
+	}))
+
+	diff, err := c.Scans().CompareScans(context.Background(), "base", "head")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(diff.Added) != 1 || len(diff.Removed) != 1 {
+		t.Fatalf("line shift should count by default: %+v", diff)
+	}
+
+	diff, err = c.Scans().CompareScans(context.Background(), "base", "head", IgnoreLineNumbers())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(diff.Unchanged) != 1 || len(diff.Added) != 0 || len(diff.Removed) != 0 {
+		t.Fatalf("diff = %+v", diff)
+	}
+}
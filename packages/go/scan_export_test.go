@@ -0,0 +1,69 @@
+package tavo
+
+import (
+	"context"
+	"encoding/csv"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func exportHandler(t *testing.T) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/scans/s1/results" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		var items []map[string]interface{}
+		switch r.URL.Query().Get("offset") {
+		case "", "0":
+			items = []map[string]interface{}{
+				{"rule_id": "sql-injection", "severity": "high", "file": "db/query.go", "line": 12, "message": "query built with \"+\", use args"},
+			}
+		case "1":
+			items = []map[string]interface{}{
+				{"rule_id": "weak-hash", "severity": "medium", "file": "a,b.go", "line": 3, "message": "md5 used\nprefer sha256", "category": "crypto"},
+			}
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{"items": items, "total": 2})
+	}
+}
+
+func TestExportResultsCSV(t *testing.T) {
+	c, _ := newTestClient(t, exportHandler(t))
+
+	var buf strings.Builder
+	if err := c.Scans().ExportResultsCSV(context.Background(), "s1", &buf); err != nil {
+		t.Fatal(err)
+	}
+	rows, err := csv.NewReader(strings.NewReader(buf.String())).ReadAll()
+	if err != nil {
+		t.Fatalf("output is not valid CSV: %v\n%s", err, buf.String())
+	}
+	want := [][]string{
+		{"rule_id", "severity", "file", "line", "message"},
+		{"sql-injection", "high", "db/query.go", "12", `query built with "+", use args`},
+		{"weak-hash", "medium", "a,b.go", "3", "md5 used\nprefer sha256"},
+	}
+	if !reflect.DeepEqual(rows, want) {
+		t.Fatalf("rows = %q", rows)
+	}
+}
+
+func TestExportResultsCSVColumns(t *testing.T) {
+	c, _ := newTestClient(t, exportHandler(t))
+
+	var buf strings.Builder
+	if err := c.Scans().ExportResultsCSV(context.Background(), "s1", &buf, WithColumns("file", "category")); err != nil {
+		t.Fatal(err)
+	}
+	if want := "file,category\ndb/query.go,\n\"a,b.go\",crypto\n"; buf.String() != want {
+		t.Fatalf("output = %q, want %q", buf.String(), want)
+	}
+
+	buf.Reset()
+	err := c.Scans().ExportResultsCSV(context.Background(), "s1", &buf, WithColumns("file", "owner"))
+	if err == nil || !strings.Contains(err.Error(), `"owner"`) || buf.Len() != 0 {
+		t.Fatalf("err = %v, output = %q", err, buf.String())
+	}
+}
@@ -0,0 +1,36 @@
+package tavo
+
+import (
+	"context"
+	"fmt"
+)
+
+// HasFindingsAtOrAbove reports whether a scan has any findings of severity
+// min or worse, and how many. It is meant for CI gates: fail the build when
+// the result is true.
+//
+// Only the matching severities are requested, and each finding's severity
+// is checked again locally, so a server that ignores the filter does not
+// cause false positives. Findings of an unrecognized severity are not
+// counted.
+func (s *ScanOperations) HasFindingsAtOrAbove(ctx context.Context, scanID string, min Severity) (bool, int, error) {
+	if min < SeverityInfo || min > SeverityCritical {
+		return false, 0, fmt.Errorf("tavo: invalid severity threshold %s", min)
+	}
+	var filter ResultFilter
+	for sev := min; sev <= SeverityCritical; sev++ {
+		filter.Severities = append(filter.Severities, sev)
+	}
+
+	count := 0
+	it := s.IterateFindings(ctx, scanID, filter)
+	for it.Next() {
+		if it.Item().SeverityLevel() >= min {
+			count++
+		}
+	}
+	if err := it.Err(); err != nil {
+		return false, 0, err
+	}
+	return count > 0, count, nil
+}
@@ -0,0 +1,59 @@
+package tavo
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestHasFindingsAtOrAbove(t *testing.T) {
+	c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/scans/s1/results" {
+			t.Errorf("path = %s", r.URL.Path)
+		}
+		if got := r.URL.Query()["severity"]; !reflect.DeepEqual(got, []string{"high", "critical"}) {
+			t.Errorf("severity = %v", got)
+		}
+		// The server ignores the filter; the SDK must still only count
+		// high and critical findings.
+		writeJSON(w, http.StatusOK, map[string]interface{}{"items": []map[string]interface{}{
+			{"rule_id": "sqli", "severity": "critical"},
+			{"rule_id": "xss", "severity": "HIGH"},
+			{"rule_id": "todo", "severity": "low"},
+			{"rule_id": "odd", "severity": "severe"},
+		}, "total": 4})
+	})
+
+	found, count, err := c.Scans().HasFindingsAtOrAbove(context.Background(), "s1", SeverityHigh)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !found || count != 2 {
+		t.Errorf("found = %v, count = %d", found, count)
+	}
+}
+
+func TestHasFindingsAtOrAboveNone(t *testing.T) {
+	c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]interface{}{"items": []interface{}{}, "total": 0})
+	})
+
+	found, count, err := c.Scans().HasFindingsAtOrAbove(context.Background(), "s1", SeverityCritical)
+	if err != nil || found || count != 0 {
+		t.Fatalf("found = %v, count = %d, err = %v", found, count, err)
+	}
+}
+
+func TestHasFindingsAtOrAboveErrors(t *testing.T) {
+	c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusNotFound, map[string]interface{}{"message": "no such scan"})
+	})
+
+	if _, _, err := c.Scans().HasFindingsAtOrAbove(context.Background(), "s1", SeverityUnknown); err == nil {
+		t.Error("SeverityUnknown threshold accepted")
+	}
+	if _, _, err := c.Scans().HasFindingsAtOrAbove(context.Background(), "missing", SeverityLow); err == nil {
+		t.Error("expected error for missing scan")
+	}
+}
@@ -0,0 +1,85 @@
+package tavo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+)
+
+// GitCredentials authenticate Tavo to a private repository, typically
+// with a deploy token. They are sent only in the request body, never in
+// the URL, and are masked when printed.
+type GitCredentials struct {
+	Username string `json:"username,omitempty"`
+	Token    string `json:"token"`
+}
+
+// String masks the token so credentials are safe to log.
+func (c GitCredentials) String() string {
+	return fmt.Sprintf("{Username:%s Token:%s}", c.Username, redacted)
+}
+
+// GoString masks the token in %#v output.
+func (c GitCredentials) GoString() string {
+	return fmt.Sprintf("tavo.GitCredentials{Username:%q, Token:%q}", c.Username, redacted)
+}
+
+// scpLikeGitURL matches the scp-style SSH form, such as
+// git@github.com:acme/app.git.
+var scpLikeGitURL = regexp.MustCompile(`^[A-Za-z0-9._-]+@[A-Za-z0-9.-]+:[^/].*$`)
+
+// CreateScanFromGit starts a scan of a Git repository instead of an
+// uploaded archive. repoURL must be an https or ssh URL (including the
+// scp-like git@host:path form); credentials embedded in an https URL are
+// rejected, pass them as opts["credentials"] (a GitCredentials) instead.
+// An empty branch scans the repository's default branch. Other opts are
+// sent as scan fields.
+func (s *ScanOperations) CreateScanFromGit(ctx context.Context, repoURL, branch string, opts map[string]interface{}) (map[string]interface{}, error) {
+	if err := validateGitURL(repoURL); err != nil {
+		return nil, err
+	}
+	data := copyParams(opts)
+	if creds, ok := data["credentials"]; ok {
+		switch c := creds.(type) {
+		case GitCredentials:
+			if c.Token == "" {
+				return nil, errors.New("tavo: git credentials need a token")
+			}
+		case *GitCredentials:
+			if c == nil || c.Token == "" {
+				return nil, errors.New("tavo: git credentials need a token")
+			}
+		default:
+			return nil, fmt.Errorf("tavo: credentials must be a GitCredentials, not %T", creds)
+		}
+	}
+	data["source"] = "git"
+	data["repo_url"] = repoURL
+	if branch != "" {
+		data["branch"] = branch
+	}
+	return s.client.makeRequest(ctx, http.MethodPost, "/scans", data, nil)
+}
+
+func validateGitURL(repoURL string) error {
+	if scpLikeGitURL.MatchString(repoURL) {
+		return nil
+	}
+	u, err := url.Parse(repoURL)
+	if err != nil || u.Host == "" {
+		return fmt.Errorf("tavo: repository URL %q is not a valid https or ssh URL", repoURL)
+	}
+	switch u.Scheme {
+	case "https":
+		if _, hasPassword := u.User.Password(); hasPassword {
+			return errors.New("tavo: repository URL must not embed credentials; use GitCredentials")
+		}
+	case "ssh":
+	default:
+		return fmt.Errorf("tavo: repository URL %q must use https or ssh", u.Redacted())
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package tavo
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// ListAllOrgScans lists the scans of every organization the caller
+// belongs to, keyed by organization ID. Organizations are enumerated with
+// ListOrganizations, then each one's scans matching params are fetched in
+// full, up to DefaultBatchConcurrency organizations at a time.
+//
+// A failure for one organization does not fail the others: the returned
+// map holds every organization that was listed successfully (with an empty
+// slice when it has no scans), and the error is a *MultiError with one
+// entry per failed organization. Once ctx is done no new organizations are
+// started and the remaining ones fail with ctx.Err(). The error is returned
+// alone only when the organizations themselves cannot be listed.
+func (s *ScanOperations) ListAllOrgScans(ctx context.Context, params map[string]interface{}) (map[string][]map[string]interface{}, error) {
+	orgs, err := FetchAll(ctx, listPages(ctx, s.client.Organizations().ListOrganizations, nil))
+	if err != nil {
+		return nil, err
+	}
+
+	results := make(map[string][]map[string]interface{}, len(orgs))
+	var (
+		errs []error
+		mu   sync.Mutex
+	)
+	record := func(orgID string, scans []map[string]interface{}, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if err != nil {
+			errs = append(errs, fmt.Errorf("tavo: listing scans of organization %s: %w", orgID, err))
+			return
+		}
+		if scans == nil {
+			scans = []map[string]interface{}{}
+		}
+		results[orgID] = scans
+	}
+
+	sem := make(chan struct{}, DefaultBatchConcurrency)
+	seen := make(map[string]bool, len(orgs))
+	var wg sync.WaitGroup
+	for _, org := range orgs {
+		orgID, _ := org["id"].(string)
+		if orgID == "" || seen[orgID] {
+			continue
+		}
+		seen[orgID] = true
+		acquired := false
+		select {
+		case <-ctx.Done():
+		case sem <- struct{}{}:
+			acquired = true
+		}
+		// Both cases may be ready at once; cancellation wins.
+		if err := ctx.Err(); err != nil {
+			if acquired {
+				<-sem
+			}
+			record(orgID, nil, err)
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			p := copyParams(params)
+			p["organization_id"] = orgID
+			scans, err := s.ListAllScans(ctx, p)
+			record(orgID, scans, err)
+		}()
+	}
+	wg.Wait()
+	return results, newMultiError(errs)
+}
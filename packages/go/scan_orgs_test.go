@@ -0,0 +1,84 @@
+package tavo
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestListAllOrgScans(t *testing.T) {
+	c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		switch r.URL.Path {
+		case "/organizations":
+			writeJSON(w, http.StatusOK, map[string]interface{}{"items": []map[string]interface{}{
+				{"id": "o1"}, {"id": "o2"}, {"id": "o3"},
+			}, "total": 3})
+		case "/scans":
+			if q.Get("status") != "completed" {
+				t.Errorf("status = %q", q.Get("status"))
+			}
+			switch q.Get("organization_id") {
+			case "o1":
+				writeJSON(w, http.StatusOK, map[string]interface{}{"items": []map[string]interface{}{
+					{"id": "s1"}, {"id": "s2"},
+				}, "total": 2})
+			case "o2":
+				writeJSON(w, http.StatusOK, map[string]interface{}{"items": []interface{}{}, "total": 0})
+			default:
+				writeJSON(w, http.StatusForbidden, map[string]interface{}{"message": "no access"})
+			}
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	})
+
+	params := map[string]interface{}{"status": "completed"}
+	results, err := c.Scans().ListAllOrgScans(context.Background(), params)
+	if len(results["o1"]) != 2 || results["o2"] == nil || len(results["o2"]) != 0 {
+		t.Errorf("results = %v", results)
+	}
+	if _, ok := results["o3"]; ok {
+		t.Error("failed organization present in results")
+	}
+	var me *MultiError
+	if !errors.As(err, &me) || len(me.Errors()) != 1 {
+		t.Fatalf("err = %v", err)
+	}
+	var te *TavoError
+	if !errors.As(err, &te) || te.StatusCode != http.StatusForbidden {
+		t.Errorf("err = %v", err)
+	}
+	if len(params) != 1 {
+		t.Errorf("params mutated: %v", params)
+	}
+}
+
+func TestListAllOrgScansListFails(t *testing.T) {
+	c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusUnauthorized, map[string]interface{}{"message": "bad key"})
+	})
+
+	results, err := c.Scans().ListAllOrgScans(context.Background(), nil)
+	if err == nil || results != nil {
+		t.Fatalf("results = %v, err = %v", results, err)
+	}
+}
+
+func TestListAllOrgScansCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/organizations" {
+			writeJSON(w, http.StatusOK, map[string]interface{}{"items": []map[string]interface{}{{"id": "o1"}}, "total": 1})
+			cancel()
+			return
+		}
+		t.Errorf("unexpected request %s", r.URL.Path)
+	})
+
+	results, err := c.Scans().ListAllOrgScans(ctx, nil)
+	if !errors.Is(err, context.Canceled) || len(results) != 0 {
+		t.Fatalf("results = %v, err = %v", results, err)
+	}
+}
@@ -0,0 +1,93 @@
+package tavo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// ScanTypes lists the scan types the API accepts in ScanRequest.ScanType.
+var ScanTypes = []string{"security", "compliance", "vulnerability"}
+
+// ScanRequest describes a scan to start with CreateScanTyped.
+type ScanRequest struct {
+	Name string `json:"name,omitempty"`
+	// Target is what to scan: a URL such as a repository URL (including
+	// the scp-like git@host:path form), or a file or directory path known
+	// to the server.
+	Target string `json:"target"`
+	// ScanType is one of ScanTypes.
+	ScanType  string `json:"scan_type"`
+	RuleSetID string `json:"rule_set_id,omitempty"`
+	// Options are extra scan settings sent as is.
+	Options map[string]interface{} `json:"options,omitempty"`
+}
+
+// Validate reports whether the request can be sent: Target must be a
+// well-formed URL or a path, and ScanType a known scan type.
+func (r ScanRequest) Validate() error {
+	if err := validateScanTarget(r.Target); err != nil {
+		return err
+	}
+	for _, t := range ScanTypes {
+		if r.ScanType == t {
+			return nil
+		}
+	}
+	return fmt.Errorf("tavo: unknown scan type %q (want one of %s)", r.ScanType, strings.Join(ScanTypes, ", "))
+}
+
+func validateScanTarget(target string) error {
+	if strings.TrimSpace(target) == "" {
+		return errors.New("tavo: scan target is required")
+	}
+	if strings.IndexFunc(target, unicode.IsControl) >= 0 {
+		return fmt.Errorf("tavo: scan target %q contains control characters", target)
+	}
+	if !strings.Contains(target, "://") || scpLikeGitURL.MatchString(target) {
+		return nil // a path, or a git@host:path URL
+	}
+	u, err := url.Parse(target)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return fmt.Errorf("tavo: scan target %q is not a valid URL", target)
+	}
+	return nil
+}
+
+// Scan is a scan as returned by the API.
+type Scan struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	Target    string    `json:"target"`
+	ScanType  string    `json:"scan_type"`
+	RuleSetID string    `json:"rule_set_id,omitempty"`
+	Status    string    `json:"status"`
+	CreatedAt time.Time `json:"created_at"`
+
+	// Extra holds response fields without a dedicated field above.
+	Extra map[string]interface{} `json:"-"`
+}
+
+// CreateScanTyped validates req and starts a scan from it. Nothing is sent
+// when validation fails, so malformed targets are caught before the server
+// queues the scan.
+func (s *ScanOperations) CreateScanTyped(ctx context.Context, req ScanRequest) (*Scan, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	resp, err := s.client.makeRequest(ctx, http.MethodPost, "/scans", req, nil)
+	if err != nil {
+		return nil, err
+	}
+	var scan Scan
+	if err := decodeMap(resp, &scan); err != nil {
+		return nil, err
+	}
+	scan.Extra = extraFields(resp, &scan)
+	return &scan, nil
+}
@@ -0,0 +1,75 @@
+package tavo
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestCreateScanTyped(t *testing.T) {
+	c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/scans" {
+			t.Errorf("unexpected %s %s", r.Method, r.URL.Path)
+		}
+		var body map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&body)
+		want := map[string]interface{}{
+			"name": "nightly", "target": "https://github.com/acme/app", "scan_type": "security",
+			"rule_set_id": "rs1", "options": map[string]interface{}{"depth": float64(2)},
+		}
+		if !reflect.DeepEqual(body, want) {
+			t.Errorf("body = %v", body)
+		}
+		writeJSON(w, http.StatusCreated, map[string]interface{}{
+			"id": "s1", "name": "nightly", "target": "https://github.com/acme/app", "scan_type": "security",
+			"rule_set_id": "rs1", "status": "queued", "created_at": "2025-03-01T12:00:00Z", "priority": "low",
+		})
+	})
+
+	scan, err := c.Scans().CreateScanTyped(context.Background(), ScanRequest{
+		Name: "nightly", Target: "https://github.com/acme/app", ScanType: "security",
+		RuleSetID: "rs1", Options: map[string]interface{}{"depth": 2},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if scan.ID != "s1" || scan.Status != "queued" || scan.CreatedAt.IsZero() || scan.Extra["priority"] != "low" {
+		t.Errorf("scan = %+v", scan)
+	}
+}
+
+func TestScanRequestValidate(t *testing.T) {
+	tests := []struct {
+		req ScanRequest
+		ok  bool
+	}{
+		{ScanRequest{Target: "https://github.com/acme/app", ScanType: "security"}, true},
+		{ScanRequest{Target: "git@github.com:acme/app.git", ScanType: "compliance"}, true},
+		{ScanRequest{Target: "./src/app", ScanType: "vulnerability"}, true},
+		{ScanRequest{Target: "/srv/repos/app", ScanType: "security"}, true},
+		{ScanRequest{Target: "", ScanType: "security"}, false},
+		{ScanRequest{Target: "   ", ScanType: "security"}, false},
+		{ScanRequest{Target: "https://", ScanType: "security"}, false},
+		{ScanRequest{Target: "://acme", ScanType: "security"}, false},
+		{ScanRequest{Target: "src/app\n", ScanType: "security"}, false},
+		{ScanRequest{Target: "src/app", ScanType: ""}, false},
+		{ScanRequest{Target: "src/app", ScanType: "Security"}, false},
+	}
+	for _, tt := range tests {
+		if err := tt.req.Validate(); (err == nil) != tt.ok {
+			t.Errorf("Validate(%+v) = %v, want ok %v", tt.req, err, tt.ok)
+		}
+	}
+}
+
+func TestCreateScanTypedInvalidNotSent(t *testing.T) {
+	c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+	})
+
+	if _, err := c.Scans().CreateScanTyped(context.Background(), ScanRequest{Target: "src", ScanType: "fuzz"}); err == nil {
+		t.Fatal("expected error")
+	}
+}
@@ -0,0 +1,142 @@
+package tavo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+)
+
+// ScanRuleOperations groups the /scan-rules endpoints.
+type ScanRuleOperations struct {
+	client *Client
+
+	schemaOnce sync.Once
+	schema     *jsonSchema
+	schemaErr  error
+}
+
+// ListRules lists scan rules.
+func (r *ScanRuleOperations) ListRules(ctx context.Context, params map[string]interface{}) (map[string]interface{}, error) {
+	return r.client.makeRequest(ctx, http.MethodGet, "/scan-rules", nil, params)
+}
+
+// GetRule fetches a scan rule by ID.
+func (r *ScanRuleOperations) GetRule(ctx context.Context, ruleID string) (map[string]interface{}, error) {
+	return r.client.makeRequest(ctx, http.MethodGet, "/scan-rules/"+ruleID, nil, nil)
+}
+
+// CreateRule creates a scan rule.
+func (r *ScanRuleOperations) CreateRule(ctx context.Context, ruleData map[string]interface{}) (map[string]interface{}, error) {
+	return r.client.makeRequest(ctx, http.MethodPost, "/scan-rules", ruleData, nil)
+}
+
+// UpdateRule updates a scan rule.
+func (r *ScanRuleOperations) UpdateRule(ctx context.Context, ruleID string, ruleData map[string]interface{}) (map[string]interface{}, error) {
+	return r.client.makeRequest(ctx, http.MethodPut, "/scan-rules/"+ruleID, ruleData, nil)
+}
+
+// DeleteRule deletes a scan rule.
+func (r *ScanRuleOperations) DeleteRule(ctx context.Context, ruleID string) error {
+	_, err := r.client.makeRequest(ctx, http.MethodDelete, "/scan-rules/"+ruleID, nil, nil)
+	return err
+}
+
+// EnableRule enables a scan rule.
+func (r *ScanRuleOperations) EnableRule(ctx context.Context, ruleID string) (map[string]interface{}, error) {
+	return r.client.makeRequest(ctx, http.MethodPost, "/scan-rules/"+ruleID+"/enable", nil, nil)
+}
+
+// DisableRule disables a scan rule.
+func (r *ScanRuleOperations) DisableRule(ctx context.Context, ruleID string) (map[string]interface{}, error) {
+	return r.client.makeRequest(ctx, http.MethodPost, "/scan-rules/"+ruleID+"/disable", nil, nil)
+}
+
+// BulkCreateRules creates several rules in one request. The API answers 207
+// when some rules are rejected: the result is then returned together with
+// a *MultiError of the rejected items, which the result's Failed method
+// also lists.
+func (r *ScanRuleOperations) BulkCreateRules(ctx context.Context, rules []map[string]interface{}) (*MultiStatusResult, error) {
+	res, err := r.client.makeBulkRequest(ctx, http.MethodPost, "/scan-rules/bulk", map[string]interface{}{"rules": rules})
+	if err != nil {
+		return nil, err
+	}
+	return res, res.Err()
+}
+
+// toggleConcurrency bounds the in-flight requests of SetRulesEnabled when
+// it falls back to toggling rules one by one.
+const toggleConcurrency = 8
+
+// SetRulesEnabled enables or disables many rules at once via
+// /scan-rules/bulk-toggle. Servers without that endpoint (404 or 405) are
+// handled by toggling each rule individually, concurrently. The summary has
+// "enabled", "succeeded" (rule IDs) and "failed" (rule ID to error
+// message); when any rule failed the error is a *MultiError of the
+// individual failures.
+func (r *ScanRuleOperations) SetRulesEnabled(ctx context.Context, ruleIDs []string, enabled bool) (map[string]interface{}, error) {
+	body := map[string]interface{}{"rule_ids": ruleIDs, "enabled": enabled}
+	res, err := r.client.makeBulkRequest(ctx, http.MethodPost, "/scan-rules/bulk-toggle", body)
+	var tErr *TavoError
+	if errors.As(err, &tErr) && (tErr.StatusCode == http.StatusNotFound || tErr.StatusCode == http.StatusMethodNotAllowed) {
+		return r.toggleEach(ctx, ruleIDs, enabled)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	failures := make(map[string]error)
+	for _, item := range res.Failed() {
+		id := item.ID
+		if id == "" && item.Index >= 0 && item.Index < len(ruleIDs) {
+			id = ruleIDs[item.Index]
+		}
+		failures[id] = item.Err
+	}
+	return toggleSummary(ruleIDs, enabled, failures)
+}
+
+// toggleEach enables or disables each rule with its own request.
+func (r *ScanRuleOperations) toggleEach(ctx context.Context, ruleIDs []string, enabled bool) (map[string]interface{}, error) {
+	toggle := r.DisableRule
+	if enabled {
+		toggle = r.EnableRule
+	}
+	failures := make(map[string]error)
+	var mu sync.Mutex
+	sem := make(chan struct{}, toggleConcurrency)
+	var wg sync.WaitGroup
+	for _, id := range ruleIDs {
+		id := id
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			if _, err := toggle(ctx, id); err != nil {
+				mu.Lock()
+				failures[id] = err
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	return toggleSummary(ruleIDs, enabled, failures)
+}
+
+func toggleSummary(ruleIDs []string, enabled bool, failures map[string]error) (map[string]interface{}, error) {
+	succeeded := make([]string, 0, len(ruleIDs))
+	failed := make(map[string]interface{}, len(failures))
+	var errs []error
+	for _, id := range ruleIDs {
+		if err, ok := failures[id]; ok {
+			failed[id] = err.Error()
+			errs = append(errs, fmt.Errorf("rule %s: %w", id, err))
+			continue
+		}
+		succeeded = append(succeeded, id)
+	}
+	summary := map[string]interface{}{"enabled": enabled, "succeeded": succeeded, "failed": failed}
+	return summary, newMultiError(errs)
+}
@@ -0,0 +1,92 @@
+package tavo
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// ScanRule is a scan rule definition.
+type ScanRule struct {
+	ID          string     `json:"id"`
+	Slug        string     `json:"slug"`
+	Name        string     `json:"name"`
+	Description string     `json:"description,omitempty"`
+	Category    string     `json:"category,omitempty"`
+	Severity    string     `json:"severity"`
+	Enabled     bool       `json:"enabled"`
+	Pattern     string     `json:"pattern,omitempty"`
+	CreatedAt   *time.Time `json:"created_at,omitempty"`
+	UpdatedAt   *time.Time `json:"updated_at,omitempty"`
+
+	// Highlights maps a rule field, such as "description", to the
+	// fragments of it that matched a SearchRules query, with the matched
+	// terms marked by the server. It is empty outside search results.
+	Highlights map[string][]string `json:"highlights,omitempty"`
+
+	// Extra holds response fields without a dedicated field above.
+	Extra map[string]interface{} `json:"-"`
+}
+
+// RuleFilter narrows the rules returned by SearchRules.
+type RuleFilter struct {
+	Category string
+	Severity string
+	// Enabled, when non-nil, keeps only enabled (true) or disabled (false)
+	// rules.
+	Enabled *bool
+	Limit   int
+	Offset  int
+}
+
+// Params returns the filter as SearchRules or ListRules params.
+func (f RuleFilter) Params() map[string]interface{} {
+	params := map[string]interface{}{}
+	if f.Category != "" {
+		params["category"] = f.Category
+	}
+	if f.Severity != "" {
+		params["severity"] = f.Severity
+	}
+	if f.Enabled != nil {
+		params["enabled"] = *f.Enabled
+	}
+	if f.Limit > 0 {
+		params["limit"] = f.Limit
+	}
+	if f.Offset > 0 {
+		params["offset"] = f.Offset
+	}
+	return params
+}
+
+// SearchRules runs a full-text search over rule names, descriptions and
+// patterns, for example for a CVE ID, and returns the matching rules with
+// their Highlights along with the total number of matches. params narrows
+// the search; build it with RuleFilter.Params to filter by category,
+// severity or enabled status.
+func (r *ScanRuleOperations) SearchRules(ctx context.Context, query string, params map[string]interface{}) ([]ScanRule, int, error) {
+	if strings.TrimSpace(query) == "" {
+		return nil, 0, errors.New("tavo: search query is required")
+	}
+	p := copyParams(params)
+	p["q"] = query
+	resp, err := r.client.makeRequest(ctx, http.MethodGet, "/scan-rules/search", nil, p)
+	if err != nil {
+		return nil, 0, err
+	}
+	offset, _ := toInt(p["offset"])
+	raw, total := pageItems(resp, offset)
+	rules := make([]ScanRule, 0, len(raw))
+	for _, m := range raw {
+		var rule ScanRule
+		if err := decodeMap(m, &rule); err != nil {
+			return nil, 0, err
+		}
+		rule.Extra = extraFields(m, &rule)
+		rules = append(rules, rule)
+	}
+	return rules, total, nil
+}
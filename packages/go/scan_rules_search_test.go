@@ -0,0 +1,72 @@
+package tavo
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestSearchRules(t *testing.T) {
+	c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/scan-rules/search" {
+			t.Errorf("path = %s", r.URL.Path)
+		}
+		q := r.URL.Query()
+		want := map[string]string{"q": "CVE-2021-44228", "category": "injection", "severity": "critical", "enabled": "true", "limit": "10"}
+		for k, v := range want {
+			if q.Get(k) != v {
+				t.Errorf("param %s = %q, want %q", k, q.Get(k), v)
+			}
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"total": 1,
+			"items": []interface{}{map[string]interface{}{
+				"id": "r1", "slug": "log4shell", "name": "Log4Shell JNDI lookup",
+				"category": "injection", "severity": "critical", "enabled": true,
+				"highlights": map[string]interface{}{"description": []string{"detects <em>CVE-2021-44228</em>"}},
+				"cwe":        "CWE-917",
+			}},
+		})
+	})
+
+	enabled := true
+	filter := RuleFilter{Category: "injection", Severity: "critical", Enabled: &enabled, Limit: 10}
+	rules, total, err := c.ScanRules().SearchRules(context.Background(), "CVE-2021-44228", filter.Params())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total != 1 || len(rules) != 1 {
+		t.Fatalf("total = %d, rules = %+v", total, rules)
+	}
+	rule := rules[0]
+	if rule.Slug != "log4shell" || !rule.Enabled || rule.Severity != "critical" {
+		t.Fatalf("rule = %+v", rule)
+	}
+	if !reflect.DeepEqual(rule.Highlights["description"], []string{"detects <em>CVE-2021-44228</em>"}) {
+		t.Fatalf("highlights = %v", rule.Highlights)
+	}
+	if rule.Extra["cwe"] != "CWE-917" {
+		t.Fatalf("extra = %v", rule.Extra)
+	}
+}
+
+func TestSearchRulesRequiresQuery(t *testing.T) {
+	c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Error("request sent for an empty query")
+	})
+	if _, _, err := c.ScanRules().SearchRules(context.Background(), "  ", nil); err == nil {
+		t.Fatal("expected an error")
+	}
+}
+
+func TestRuleFilterParamsDisabled(t *testing.T) {
+	disabled := false
+	p := RuleFilter{Enabled: &disabled}.Params()
+	if v, ok := p["enabled"]; !ok || v != false {
+		t.Fatalf("params = %v", p)
+	}
+	if len(RuleFilter{}.Params()) != 0 {
+		t.Fatal("zero filter produced params")
+	}
+}
@@ -0,0 +1,96 @@
+package tavo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestSetRulesEnabledBulk(t *testing.T) {
+	c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/scan-rules/bulk-toggle" {
+			t.Errorf("unexpected %s %s", r.Method, r.URL.Path)
+		}
+		var body struct {
+			RuleIDs []string `json:"rule_ids"`
+			Enabled bool     `json:"enabled"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		if !reflect.DeepEqual(body.RuleIDs, []string{"r1", "r2", "r3"}) || body.Enabled {
+			t.Errorf("body = %+v", body)
+		}
+		writeJSON(w, http.StatusMultiStatus, map[string]interface{}{
+			"results": []map[string]interface{}{
+				{"index": 0, "id": "r1", "status": 200},
+				{"index": 1, "status": 403, "error": map[string]interface{}{"message": "built-in rule"}},
+				{"index": 2, "id": "r3", "status": 200},
+			},
+		})
+	})
+
+	summary, err := c.ScanRules().SetRulesEnabled(context.Background(), []string{"r1", "r2", "r3"}, false)
+	if err == nil || !errors.Is(err, ErrUnauthorized) || !strings.Contains(err.Error(), "rule r2") {
+		t.Fatalf("err = %v", err)
+	}
+	if !reflect.DeepEqual(summary["succeeded"], []string{"r1", "r3"}) || summary["enabled"] != false {
+		t.Fatalf("summary = %v", summary)
+	}
+	if failed := summary["failed"].(map[string]interface{}); len(failed) != 1 || !strings.Contains(failed["r2"].(string), "built-in rule") {
+		t.Fatalf("failed = %v", failed)
+	}
+}
+
+func TestSetRulesEnabledFallsBackToIndividualToggles(t *testing.T) {
+	var mu sync.Mutex
+	var toggled []string
+	c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/scan-rules/bulk-toggle":
+			writeJSON(w, http.StatusNotFound, map[string]interface{}{"message": "not found"})
+		case r.URL.Path == "/scan-rules/bad/enable":
+			writeJSON(w, http.StatusUnprocessableEntity, map[string]interface{}{"message": "rule is invalid"})
+		case strings.HasSuffix(r.URL.Path, "/enable"):
+			mu.Lock()
+			toggled = append(toggled, r.URL.Path)
+			mu.Unlock()
+			writeJSON(w, http.StatusOK, map[string]interface{}{"enabled": true})
+		default:
+			t.Errorf("unexpected %s", r.URL.Path)
+		}
+	})
+
+	ids := []string{"r1", "bad", "r2", "r3"}
+	summary, err := c.ScanRules().SetRulesEnabled(context.Background(), ids, true)
+	if err == nil || !strings.Contains(err.Error(), "rule is invalid") {
+		t.Fatalf("err = %v", err)
+	}
+	if !reflect.DeepEqual(summary["succeeded"], []string{"r1", "r2", "r3"}) {
+		t.Fatalf("summary = %v", summary)
+	}
+	if _, ok := summary["failed"].(map[string]interface{})["bad"]; !ok {
+		t.Fatalf("failed = %v", summary["failed"])
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if len(toggled) != 3 {
+		t.Fatalf("toggled = %v", toggled)
+	}
+}
+
+func TestSetRulesEnabledAllSucceed(t *testing.T) {
+	c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]interface{}{})
+	})
+	summary, err := c.ScanRules().SetRulesEnabled(context.Background(), []string{"r1", "r2"}, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(summary["succeeded"].([]string)) != 2 || len(summary["failed"].(map[string]interface{})) != 0 {
+		t.Fatalf("summary = %v", summary)
+	}
+}
@@ -0,0 +1,92 @@
+package tavo
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+)
+
+// RuleValidation is the outcome of validating a scan rule.
+type RuleValidation struct {
+	Valid    bool     `json:"valid"`
+	Errors   []string `json:"errors,omitempty"`
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// ValidateRule checks a rule definition without saving it. Obviously
+// broken patterns are caught locally and reported without a request: a
+// "regex" field (or a "pattern" with pattern_type "regex") must compile as
+// a Go regular expression, and a semgrep-style "pattern" must have
+// balanced brackets. Go's regexp syntax is close to, but not the same as,
+// the server's, so a pattern that passes here can still be rejected by the
+// server.
+func (r *ScanRuleOperations) ValidateRule(ctx context.Context, ruleData map[string]interface{}) (*RuleValidation, error) {
+	if errs := checkRulePatterns(ruleData); len(errs) > 0 {
+		return &RuleValidation{Valid: false, Errors: errs}, nil
+	}
+	resp, err := r.client.makeRequest(ctx, http.MethodPost, "/scan-rules/validate", ruleData, nil)
+	if err != nil {
+		return nil, err
+	}
+	var v RuleValidation
+	if err := decodeMap(resp, &v); err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+func checkRulePatterns(rule map[string]interface{}) []string {
+	var errs []string
+	if re, ok := rule["regex"].(string); ok {
+		if _, err := regexp.Compile(re); err != nil {
+			errs = append(errs, fmt.Sprintf("regex: %v", err))
+		}
+	}
+	if pattern, ok := rule["pattern"].(string); ok {
+		if rule["pattern_type"] == "regex" {
+			if _, err := regexp.Compile(pattern); err != nil {
+				errs = append(errs, fmt.Sprintf("pattern: %v", err))
+			}
+		} else if err := checkBrackets(pattern); err != nil {
+			errs = append(errs, fmt.Sprintf("pattern: %v", err))
+		}
+	}
+	return errs
+}
+
+// checkBrackets reports unbalanced (), [] or {} outside string literals.
+func checkBrackets(pattern string) error {
+	pairs := map[rune]rune{')': '(', ']': '[', '}': '{'}
+	var stack []rune
+	var quote rune
+	escaped := false
+	for i, c := range pattern {
+		switch {
+		case escaped:
+			escaped = false
+		case c == '\\':
+			escaped = true
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '(' || c == '[' || c == '{':
+			stack = append(stack, c)
+		case pairs[c] != 0:
+			if len(stack) == 0 || stack[len(stack)-1] != pairs[c] {
+				return fmt.Errorf("unexpected %q at offset %d", c, i)
+			}
+			stack = stack[:len(stack)-1]
+		}
+	}
+	if quote != 0 {
+		return fmt.Errorf("unterminated string literal")
+	}
+	if len(stack) > 0 {
+		return fmt.Errorf("unclosed %q", stack[len(stack)-1])
+	}
+	return nil
+}
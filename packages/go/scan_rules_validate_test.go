@@ -0,0 +1,52 @@
+package tavo
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestValidateRuleServer(t *testing.T) {
+	c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/scan-rules/validate" {
+			t.Errorf("unexpected %s %s", r.Method, r.URL.Path)
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"valid":    true,
+			"warnings": []string{"pattern matches every file"},
+		})
+	})
+
+	v, err := c.ScanRules().ValidateRule(context.Background(), map[string]interface{}{
+		"pattern": `exec.Command($CMD, ...)`,
+		"regex":   `password\s*=\s*"[^"]+"`,
+	})
+	if err != nil || !v.Valid || len(v.Warnings) != 1 {
+		t.Fatalf("validation = %+v, err = %v", v, err)
+	}
+}
+
+func TestValidateRuleClientSide(t *testing.T) {
+	c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("request sent for a locally invalid rule")
+	})
+
+	for name, rule := range map[string]map[string]interface{}{
+		"bad regex":         {"regex": `([a-z]+`},
+		"bad regex pattern": {"pattern": `a{2,1}`, "pattern_type": "regex"},
+		"unbalanced":        {"pattern": `foo($X, bar(`},
+		"mismatched":        {"pattern": `foo($X]`},
+		"unterminated":      {"pattern": `foo("bar)`},
+	} {
+		v, err := c.ScanRules().ValidateRule(context.Background(), rule)
+		if err != nil || v.Valid || len(v.Errors) != 1 {
+			t.Errorf("%s: validation = %+v, err = %v", name, v, err)
+		}
+	}
+}
+
+func TestCheckBracketsIgnoresStrings(t *testing.T) {
+	if err := checkBrackets(`log("(unbalanced in string", $X)`); err != nil {
+		t.Fatal(err)
+	}
+}
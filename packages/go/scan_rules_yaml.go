@@ -0,0 +1,164 @@
+package tavo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ruleServerFields are set by the server and left out of exported rules.
+var ruleServerFields = []string{"id", "created_at", "updated_at", "created_by", "updated_by", "organization_id"}
+
+// rulesDocument is the YAML layout read by ImportRules and written by
+// ExportRules:
+//
+//	rules:
+//	  - slug: no-hardcoded-secrets
+//	    name: No hardcoded secrets
+//	    severity: high
+//	    pattern: ...
+type rulesDocument struct {
+	Rules []map[string]interface{} `yaml:"rules"`
+}
+
+// ExportRules returns the definitions of the given rules as a YAML
+// document suitable for ImportRules. Server-managed fields such as id and
+// timestamps are omitted.
+func (r *ScanRuleOperations) ExportRules(ctx context.Context, ruleIDs []string) ([]byte, error) {
+	doc := rulesDocument{Rules: make([]map[string]interface{}, 0, len(ruleIDs))}
+	for _, id := range ruleIDs {
+		rule, err := r.GetRule(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		rule = copyParams(rule)
+		for _, field := range ruleServerFields {
+			delete(rule, field)
+		}
+		doc.Rules = append(doc.Rules, rule)
+	}
+	return yaml.Marshal(doc)
+}
+
+// ImportRules creates or updates the rules in a YAML document written by
+// ExportRules, matching existing rules by slug so repeated imports are
+// idempotent. The whole document is validated before any request is sent.
+// New rules are created in one /scan-rules/bulk request and existing ones
+// updated individually. Each result holds the rule's "slug", the "action"
+// taken ("created" or "updated") and the server's "rule". Rules that fail
+// do not stop the import: the results of the others are returned together
+// with a *MultiError naming each failed rule.
+func (r *ScanRuleOperations) ImportRules(ctx context.Context, yamlData []byte) ([]map[string]interface{}, error) {
+	rules, err := parseRulesYAML(yamlData)
+	if err != nil {
+		return nil, err
+	}
+	existing, err := r.ruleIDsBySlug(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	outcomes := make([]map[string]interface{}, len(rules))
+	errs := make([]error, len(rules))
+	var (
+		creates  []int
+		newRules []map[string]interface{}
+	)
+	for i, rule := range rules {
+		if _, ok := existing[rule["slug"].(string)]; !ok {
+			creates = append(creates, i)
+			newRules = append(newRules, rule)
+		}
+	}
+	if len(creates) > 0 {
+		res, err := r.client.makeBulkRequest(ctx, http.MethodPost, "/scan-rules/bulk", map[string]interface{}{"rules": newRules})
+		if err != nil {
+			return nil, fmt.Errorf("tavo: importing rules: %w", err)
+		}
+		for _, i := range creates {
+			errs[i] = errors.New("tavo: server reported no result")
+		}
+		for _, item := range res.Results {
+			if item.Index < 0 || item.Index >= len(creates) {
+				continue
+			}
+			i := creates[item.Index]
+			if !item.OK() {
+				errs[i] = item.Err
+				continue
+			}
+			errs[i] = nil
+			outcomes[i] = map[string]interface{}{"slug": rules[i]["slug"], "action": "created", "rule": item.Data}
+		}
+	}
+	for i, rule := range rules {
+		id, ok := existing[rule["slug"].(string)]
+		if !ok {
+			continue
+		}
+		resp, err := r.UpdateRule(ctx, id, rule)
+		if err != nil {
+			errs[i] = err
+			continue
+		}
+		outcomes[i] = map[string]interface{}{"slug": rule["slug"], "action": "updated", "rule": resp}
+	}
+
+	results := make([]map[string]interface{}, 0, len(rules))
+	var failed []error
+	for i, outcome := range outcomes {
+		if errs[i] != nil {
+			failed = append(failed, fmt.Errorf("tavo: importing rule %q: %w", rules[i]["slug"], errs[i]))
+			continue
+		}
+		results = append(results, outcome)
+	}
+	return results, newMultiError(failed)
+}
+
+func parseRulesYAML(data []byte) ([]map[string]interface{}, error) {
+	var doc rulesDocument
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("tavo: parsing rules YAML: %w", err)
+	}
+	if len(doc.Rules) == 0 {
+		return nil, fmt.Errorf("tavo: rules YAML has no rules")
+	}
+	seen := make(map[string]bool, len(doc.Rules))
+	for i, rule := range doc.Rules {
+		slug, _ := rule["slug"].(string)
+		if slug == "" {
+			return nil, fmt.Errorf("tavo: rule %d has no slug", i)
+		}
+		if seen[slug] {
+			return nil, fmt.Errorf("tavo: duplicate rule slug %q", slug)
+		}
+		seen[slug] = true
+	}
+	return doc.Rules, nil
+}
+
+// ruleIDsBySlug maps the slug of every existing rule to its ID.
+func (r *ScanRuleOperations) ruleIDsBySlug(ctx context.Context) (map[string]string, error) {
+	it := newIterator(ctx, func(ctx context.Context, offset int) ([]map[string]interface{}, int, error) {
+		resp, err := r.ListRules(ctx, map[string]interface{}{"offset": offset, "limit": DefaultPageSize})
+		if err != nil {
+			return nil, 0, err
+		}
+		items, total := pageItems(resp, offset)
+		return items, total, nil
+	})
+	ids := make(map[string]string)
+	for it.Next() {
+		rule := it.Item()
+		slug, _ := rule["slug"].(string)
+		id, _ := rule["id"].(string)
+		if slug != "" && id != "" {
+			ids[slug] = id
+		}
+	}
+	return ids, it.Err()
+}
@@ -0,0 +1,151 @@
+package tavo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestExportRules(t *testing.T) {
+	c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"id": "r1", "slug": "no-secrets", "name": "No secrets", "severity": "high",
+			"created_at": "2025-01-01T00:00:00Z",
+		})
+	})
+
+	out, err := c.ScanRules().ExportRules(context.Background(), []string{"r1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var doc rulesDocument
+	if err := yaml.Unmarshal(out, &doc); err != nil {
+		t.Fatal(err)
+	}
+	if len(doc.Rules) != 1 || doc.Rules[0]["slug"] != "no-secrets" {
+		t.Fatalf("doc = %s", out)
+	}
+	if _, ok := doc.Rules[0]["id"]; ok {
+		t.Fatalf("server field exported: %s", out)
+	}
+}
+
+func TestImportRulesCreatesAndUpdatesBySlug(t *testing.T) {
+	var created, updated []string
+	c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		if r.Body != nil {
+			json.NewDecoder(r.Body).Decode(&body)
+		}
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/scan-rules":
+			writeJSON(w, http.StatusOK, map[string]interface{}{
+				"items": []interface{}{map[string]interface{}{"id": "r1", "slug": "existing"}},
+				"total": 1,
+			})
+		case r.Method == http.MethodPut && r.URL.Path == "/scan-rules/r1":
+			updated = append(updated, body["slug"].(string))
+			writeJSON(w, http.StatusOK, map[string]interface{}{"id": "r1", "slug": "existing"})
+		case r.Method == http.MethodPost && r.URL.Path == "/scan-rules/bulk":
+			var results []interface{}
+			for _, rule := range body["rules"].([]interface{}) {
+				slug := rule.(map[string]interface{})["slug"].(string)
+				created = append(created, slug)
+				results = append(results, map[string]interface{}{"id": "r2", "status": 201, "data": map[string]interface{}{"id": "r2", "slug": slug}})
+			}
+			writeJSON(w, http.StatusOK, map[string]interface{}{"results": results})
+		default:
+			t.Errorf("unexpected %s %s", r.Method, r.URL.Path)
+		}
+	})
+
+	data := []byte(`
+rules:
+  - slug: existing
+    name: Existing rule
+    severity: medium
+  - slug: brand-new
+    name: New rule
+    severity: high
+`)
+	results, err := c.ScanRules().ImportRules(context.Background(), data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(updated) != 1 || len(created) != 1 || created[0] != "brand-new" {
+		t.Fatalf("created = %v, updated = %v", created, updated)
+	}
+	if results[0]["action"] != "updated" || results[1]["action"] != "created" {
+		t.Fatalf("results = %v", results)
+	}
+}
+
+func TestImportRulesPartialFailure(t *testing.T) {
+	c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/scan-rules":
+			writeJSON(w, http.StatusOK, map[string]interface{}{
+				"items": []interface{}{
+					map[string]interface{}{"id": "r1", "slug": "ok-update"},
+					map[string]interface{}{"id": "r9", "slug": "bad-update"},
+				},
+				"total": 2,
+			})
+		case r.Method == http.MethodPut && r.URL.Path == "/scan-rules/r1":
+			writeJSON(w, http.StatusOK, map[string]interface{}{"id": "r1"})
+		case r.Method == http.MethodPut && r.URL.Path == "/scan-rules/r9":
+			writeJSON(w, http.StatusConflict, map[string]interface{}{"message": "locked"})
+		case r.Method == http.MethodPost && r.URL.Path == "/scan-rules/bulk":
+			writeJSON(w, http.StatusMultiStatus, map[string]interface{}{"results": []interface{}{
+				map[string]interface{}{"index": 0, "status": 201, "data": map[string]interface{}{"id": "r2"}},
+				map[string]interface{}{"index": 1, "status": 422, "error": map[string]interface{}{"message": "bad pattern"}},
+			}})
+		default:
+			t.Errorf("unexpected %s %s", r.Method, r.URL.Path)
+		}
+	})
+
+	data := []byte(`
+rules:
+  - {slug: ok-update, name: A, severity: low}
+  - {slug: ok-create, name: B, severity: low}
+  - {slug: bad-update, name: C, severity: low}
+  - {slug: bad-create, name: D, severity: low}
+`)
+	results, err := c.ScanRules().ImportRules(context.Background(), data)
+	var me *MultiError
+	if !errors.As(err, &me) || len(me.Errors()) != 2 {
+		t.Fatalf("err = %v", err)
+	}
+	if !strings.Contains(err.Error(), `"bad-update"`) || !strings.Contains(err.Error(), `"bad-create"`) {
+		t.Fatalf("err = %v", err)
+	}
+	if len(results) != 2 || results[0]["slug"] != "ok-update" || results[1]["slug"] != "ok-create" || results[1]["action"] != "created" {
+		t.Fatalf("results = %v", results)
+	}
+}
+
+func TestImportRulesValidatesBeforeSending(t *testing.T) {
+	c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("request sent for invalid YAML: %s %s", r.Method, r.URL.Path)
+	})
+
+	cases := map[string]string{
+		"malformed":      "rules: [",
+		"empty":          "rules: []",
+		"missing slug":   "rules:\n  - name: x\n",
+		"duplicate slug": "rules:\n  - slug: a\n  - slug: a\n",
+	}
+	for name, data := range cases {
+		if _, err := c.ScanRules().ImportRules(context.Background(), []byte(data)); err == nil {
+			t.Errorf("%s: expected error", name)
+		} else if !strings.HasPrefix(err.Error(), "tavo:") {
+			t.Errorf("%s: err = %v", name, err)
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package tavo
+
+import (
+	"context"
+	"time"
+)
+
+// ScanStatus is a scan's progress, suitable for driving a progress bar.
+type ScanStatus struct {
+	State string `json:"status"`
+	// Progress is the percentage complete, from 0 to 100.
+	Progress     float64    `json:"progress"`
+	FilesScanned int        `json:"files_scanned"`
+	TotalFiles   int        `json:"total_files"`
+	StartedAt    *time.Time `json:"started_at,omitempty"`
+	// ETA is the estimated time remaining: the server's estimate when it
+	// sends one, otherwise extrapolated from Progress and the time elapsed
+	// since StartedAt. It is zero when no estimate is possible and once
+	// the scan has finished.
+	ETA time.Duration `json:"-"`
+}
+
+// Done reports whether the scan has completed, failed or been cancelled.
+func (s *ScanStatus) Done() bool { return isTerminalScanStatus(s.State) }
+
+// GetScanStatusTyped fetches a scan's status as a ScanStatus.
+func (s *ScanOperations) GetScanStatusTyped(ctx context.Context, scanID string) (*ScanStatus, error) {
+	resp, err := s.GetScanStatus(ctx, scanID)
+	if err != nil {
+		return nil, err
+	}
+	var status ScanStatus
+	if err := decodeMap(resp, &status); err != nil {
+		return nil, err
+	}
+	if secs, ok := resp["eta_seconds"].(float64); ok {
+		status.ETA = time.Duration(secs * float64(time.Second))
+	} else {
+		status.ETA = estimateETA(&status, time.Now())
+	}
+	if status.Done() {
+		status.ETA = 0
+	}
+	return &status, nil
+}
+
+// estimateETA extrapolates the remaining time linearly from the progress
+// made since the scan started.
+func estimateETA(s *ScanStatus, now time.Time) time.Duration {
+	if s.StartedAt == nil || s.Progress <= 0 || s.Progress >= 100 {
+		return 0
+	}
+	elapsed := now.Sub(*s.StartedAt)
+	if elapsed <= 0 {
+		return 0
+	}
+	return time.Duration(float64(elapsed) * (100 - s.Progress) / s.Progress)
+}
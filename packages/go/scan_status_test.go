@@ -0,0 +1,67 @@
+package tavo
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestGetScanStatusTyped(t *testing.T) {
+	started := time.Now().Add(-30 * time.Second).UTC()
+	bodies := map[string]map[string]interface{}{
+		"server-eta": {"status": "running", "progress": 40.0, "files_scanned": 40, "total_files": 100, "eta_seconds": 12.5},
+		"estimated":  {"status": "running", "progress": 25.0, "started_at": started.Format(time.RFC3339Nano)},
+		"done":       {"status": "completed", "progress": 100.0, "eta_seconds": 5},
+	}
+	c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		id := r.URL.Path[len("/scans/") : len(r.URL.Path)-len("/status")]
+		writeJSON(w, http.StatusOK, bodies[id])
+	})
+	ctx := context.Background()
+
+	st, err := c.Scans().GetScanStatusTyped(ctx, "server-eta")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if st.State != "running" || st.Progress != 40 || st.FilesScanned != 40 || st.TotalFiles != 100 || st.ETA != 12500*time.Millisecond {
+		t.Fatalf("status = %+v", st)
+	}
+
+	st, err = c.Scans().GetScanStatusTyped(ctx, "estimated")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 25% took ~30s, so ~90s remain.
+	if st.ETA < 85*time.Second || st.ETA > 95*time.Second {
+		t.Fatalf("estimated ETA = %s", st.ETA)
+	}
+
+	st, err = c.Scans().GetScanStatusTyped(ctx, "done")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !st.Done() || st.ETA != 0 {
+		t.Fatalf("finished status = %+v", st)
+	}
+}
+
+func TestEstimateETA(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	start := now.Add(-time.Minute)
+	for _, tc := range []struct {
+		progress float64
+		started  *time.Time
+		want     time.Duration
+	}{
+		{50, &start, time.Minute},
+		{75, &start, 20 * time.Second},
+		{0, &start, 0},
+		{100, &start, 0},
+		{50, nil, 0},
+	} {
+		if got := estimateETA(&ScanStatus{Progress: tc.progress, StartedAt: tc.started}, now); got != tc.want {
+			t.Errorf("progress %v: ETA = %s, want %s", tc.progress, got, tc.want)
+		}
+	}
+}
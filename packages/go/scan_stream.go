@@ -0,0 +1,127 @@
+package tavo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// resultArrayKeys are the object keys under which a results response may
+// hold its findings.
+var resultArrayKeys = map[string]bool{"items": true, "results": true, "findings": true}
+
+// StreamScanResults fetches all of a scan's findings and calls fn for each
+// one as it is decoded, so memory use does not grow with the result set.
+// Results are requested DefaultPageSize at a time and each page is decoded
+// as it arrives. A page may be a bare JSON array or an object holding the
+// array under "items", "results" or "findings", with the overall count
+// under "total". If fn returns an error, streaming stops and that error is
+// returned.
+func (s *ScanOperations) StreamScanResults(ctx context.Context, scanID string, fn func(finding map[string]interface{}) error) error {
+	path := "/scans/" + scanID + "/results"
+	var firstID interface{}
+	for offset, page := 0, 0; ; page++ {
+		if page == fetchAllMaxPages {
+			return fmt.Errorf("tavo: pagination did not end after %d pages", fetchAllMaxPages)
+		}
+		params := map[string]interface{}{"offset": offset, "limit": DefaultPageSize}
+		first := true
+		n, total, err := s.streamResultsPage(ctx, path, params, func(f map[string]interface{}) error {
+			if first {
+				first = false
+				if page > 0 && fmt.Sprint(f["id"]) == fmt.Sprint(firstID) {
+					return fmt.Errorf("tavo: pagination repeated item %v at offset %d", f["id"], offset)
+				}
+				firstID = f["id"]
+			}
+			return fn(f)
+		})
+		if err != nil {
+			return err
+		}
+		offset += n
+		if n == 0 || (total >= 0 && offset >= total) || (total < 0 && n < DefaultPageSize) {
+			return nil
+		}
+	}
+}
+
+// streamResultsPage streams one page of results to fn and returns the
+// number of findings it held and the "total" it reported, or -1 if it
+// reported none.
+func (s *ScanOperations) streamResultsPage(ctx context.Context, path string, params map[string]interface{}, fn func(map[string]interface{}) error) (int, int, error) {
+	body, _, err := s.client.openStream(ctx, path, params, "application/json")
+	if err != nil {
+		return 0, -1, err
+	}
+	defer body.Close()
+
+	dec := json.NewDecoder(body)
+	tok, err := dec.Token()
+	if err != nil {
+		return 0, -1, fmt.Errorf("tavo: reading %s: %w", path, err)
+	}
+	switch tok {
+	case json.Delim('['):
+		n, err := streamArray(dec, path, fn)
+		return n, -1, err
+	case json.Delim('{'):
+	default:
+		return 0, -1, fmt.Errorf("tavo: reading %s: unexpected %v", path, tok)
+	}
+
+	n, total := 0, -1
+	for dec.More() {
+		key, err := dec.Token()
+		if err != nil {
+			return n, total, fmt.Errorf("tavo: reading %s: %w", path, err)
+		}
+		k, _ := key.(string)
+		if resultArrayKeys[k] {
+			tok, err := dec.Token()
+			if err != nil {
+				return n, total, fmt.Errorf("tavo: reading %s: %w", path, err)
+			}
+			if tok == json.Delim('[') {
+				m, err := streamArray(dec, path, fn)
+				n += m
+				if err != nil {
+					return n, total, err
+				}
+			}
+			continue
+		}
+		var value json.RawMessage
+		if err := dec.Decode(&value); err != nil {
+			return n, total, fmt.Errorf("tavo: reading %s: %w", path, err)
+		}
+		if k == "total" {
+			var t int
+			if json.Unmarshal(value, &t) == nil {
+				total = t
+			}
+		}
+	}
+	return n, total, nil
+}
+
+// streamArray decodes the elements of an array whose opening bracket has
+// been consumed and returns how many it passed to fn.
+func streamArray(dec *json.Decoder, path string, fn func(map[string]interface{}) error) (int, error) {
+	n := 0
+	for dec.More() {
+		var finding map[string]interface{}
+		if err := dec.Decode(&finding); err != nil {
+			return n, fmt.Errorf("tavo: reading %s: %w", path, err)
+		}
+		n++
+		if err := fn(finding); err != nil {
+			return n, err
+		}
+	}
+	if _, err := dec.Token(); err != nil && err != io.EOF {
+		return n, fmt.Errorf("tavo: reading %s: %w", path, err)
+	}
+	return n, nil
+}
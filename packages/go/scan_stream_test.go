@@ -0,0 +1,117 @@
+package tavo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestStreamScanResults(t *testing.T) {
+	bodies := map[string]string{
+		"array":  `[{"id":"f0"},{"id":"f1"},{"id":"f2"}]`,
+		"object": `{"scan_id":"s1","meta":{"x":[1,2]},"items":[{"id":"f0"},{"id":"f1"},{"id":"f2"}],"total":3}`,
+	}
+	for name, body := range bodies {
+		t.Run(name, func(t *testing.T) {
+			c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/scans/s1/results" {
+					t.Errorf("path = %s", r.URL.Path)
+				}
+				fmt.Fprint(w, body)
+			})
+			var ids []string
+			err := c.Scans().StreamScanResults(context.Background(), "s1", func(f map[string]interface{}) error {
+				ids = append(ids, f["id"].(string))
+				return nil
+			})
+			if err != nil || strings.Join(ids, ",") != "f0,f1,f2" {
+				t.Fatalf("ids = %v, err = %v", ids, err)
+			}
+		})
+	}
+}
+
+func TestStreamScanResultsPaginates(t *testing.T) {
+	const total = 2*DefaultPageSize + 7
+	for _, withTotal := range []bool{true, false} {
+		t.Run(fmt.Sprintf("total=%v", withTotal), func(t *testing.T) {
+			var pages []string
+			c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+				limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+				pages = append(pages, r.URL.RawQuery)
+				var items []string
+				for i := offset; i < total && i < offset+limit; i++ {
+					items = append(items, fmt.Sprintf(`{"id":"f%d"}`, i))
+				}
+				if withTotal {
+					fmt.Fprintf(w, `{"items":[%s],"total":%d}`, strings.Join(items, ","), total)
+					return
+				}
+				fmt.Fprintf(w, "[%s]", strings.Join(items, ","))
+			})
+			var ids []string
+			err := c.Scans().StreamScanResults(context.Background(), "s1", func(f map[string]interface{}) error {
+				ids = append(ids, f["id"].(string))
+				return nil
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(ids) != total || ids[0] != "f0" || ids[total-1] != fmt.Sprintf("f%d", total-1) {
+				t.Fatalf("got %d findings: first %v", len(ids), ids[:3])
+			}
+			if len(pages) != 3 || pages[2] != fmt.Sprintf("limit=%d&offset=%d", DefaultPageSize, 2*DefaultPageSize) {
+				t.Fatalf("pages = %v", pages)
+			}
+		})
+	}
+}
+
+func TestStreamScanResultsStopsOnCallbackError(t *testing.T) {
+	c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("["))
+		for i := 0; i < 10000; i++ {
+			if i > 0 {
+				w.Write([]byte(","))
+			}
+			fmt.Fprintf(w, `{"id":"f%d"}`, i)
+		}
+		w.Write([]byte("]"))
+	})
+	stop := errors.New("enough")
+	seen := 0
+	err := c.Scans().StreamScanResults(context.Background(), "s1", func(map[string]interface{}) error {
+		seen++
+		if seen == 5 {
+			return stop
+		}
+		return nil
+	})
+	if !errors.Is(err, stop) || seen != 5 {
+		t.Fatalf("err = %v, seen = %d", err, seen)
+	}
+}
+
+func TestStreamScanResultsErrors(t *testing.T) {
+	c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/scans/missing/results" {
+			writeJSON(w, http.StatusNotFound, map[string]interface{}{"message": "no scan"})
+			return
+		}
+		fmt.Fprint(w, `[{"id":"f0"},{"id":`)
+	})
+	noop := func(map[string]interface{}) error { return nil }
+
+	var tErr *TavoError
+	if err := c.Scans().StreamScanResults(context.Background(), "missing", noop); !errors.As(err, &tErr) || tErr.StatusCode != 404 {
+		t.Fatalf("err = %v", err)
+	}
+	if err := c.Scans().StreamScanResults(context.Background(), "truncated", noop); err == nil {
+		t.Fatal("expected error for truncated body")
+	}
+}
@@ -0,0 +1,53 @@
+package tavo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+)
+
+// tagPattern is the charset accepted for scan tags.
+var tagPattern = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// ValidateTags checks that every tag is non-empty and made of letters,
+// digits, dashes and underscores.
+func ValidateTags(tags []string) error {
+	if len(tags) == 0 {
+		return errors.New("tavo: no tags given")
+	}
+	for _, tag := range tags {
+		if !tagPattern.MatchString(tag) {
+			return fmt.Errorf("tavo: invalid tag %q: use letters, digits, '-' and '_'", tag)
+		}
+	}
+	return nil
+}
+
+// AddTags adds tags to a scan. Tags already on the scan are left as is.
+func (s *ScanOperations) AddTags(ctx context.Context, scanID string, tags []string) (map[string]interface{}, error) {
+	if err := ValidateTags(tags); err != nil {
+		return nil, err
+	}
+	return s.client.makeRequest(ctx, http.MethodPost, "/scans/"+scanID+"/tags", tags, nil)
+}
+
+// RemoveTags removes tags from a scan.
+func (s *ScanOperations) RemoveTags(ctx context.Context, scanID string, tags []string) (map[string]interface{}, error) {
+	if err := ValidateTags(tags); err != nil {
+		return nil, err
+	}
+	return s.client.makeRequest(ctx, http.MethodDelete, "/scans/"+scanID+"/tags", tags, nil)
+}
+
+// ListScansByTag lists scans carrying tag. params are passed through as
+// for ListScans.
+func (s *ScanOperations) ListScansByTag(ctx context.Context, tag string, params map[string]interface{}) (map[string]interface{}, error) {
+	if err := ValidateTags([]string{tag}); err != nil {
+		return nil, err
+	}
+	p := copyParams(params)
+	p["tag"] = tag
+	return s.ListScans(ctx, p)
+}
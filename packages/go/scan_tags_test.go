@@ -0,0 +1,72 @@
+package tavo
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestScanTags(t *testing.T) {
+	var got []string
+	var method string
+	c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/scans/s1/tags":
+			method = r.Method
+			got = nil
+			if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+				t.Error(err)
+			}
+			writeJSON(w, http.StatusOK, map[string]interface{}{"tags": got})
+		case "/scans":
+			if r.URL.Query().Get("tag") != "team-payments" || r.URL.Query().Get("limit") != "5" {
+				t.Errorf("query = %s", r.URL.RawQuery)
+			}
+			writeJSON(w, http.StatusOK, map[string]interface{}{"items": []interface{}{}})
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	})
+	ctx := context.Background()
+	tags := []string{"team-payments", "env_prod"}
+
+	if _, err := c.Scans().AddTags(ctx, "s1", tags); err != nil {
+		t.Fatal(err)
+	}
+	if method != http.MethodPost || !reflect.DeepEqual(got, tags) {
+		t.Fatalf("%s %v", method, got)
+	}
+	if _, err := c.Scans().RemoveTags(ctx, "s1", tags[:1]); err != nil {
+		t.Fatal(err)
+	}
+	if method != http.MethodDelete || !reflect.DeepEqual(got, tags[:1]) {
+		t.Fatalf("%s %v", method, got)
+	}
+	params := map[string]interface{}{"limit": 5}
+	if _, err := c.Scans().ListScansByTag(ctx, "team-payments", params); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := params["tag"]; ok {
+		t.Fatal("caller's params were modified")
+	}
+}
+
+func TestValidateTags(t *testing.T) {
+	for _, tags := range [][]string{nil, {""}, {"ok", "has space"}, {"a/b"}, {"ümlaut"}, {"semi;colon"}} {
+		if err := ValidateTags(tags); err == nil {
+			t.Errorf("ValidateTags(%q) accepted", tags)
+		}
+	}
+	if err := ValidateTags([]string{"A-z_09"}); err != nil {
+		t.Error(err)
+	}
+
+	c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Error("request sent with invalid tags")
+	})
+	if _, err := c.Scans().AddTags(context.Background(), "s1", []string{"bad tag"}); err == nil {
+		t.Fatal("AddTags accepted an invalid tag")
+	}
+}
@@ -0,0 +1,78 @@
+package tavo
+
+import (
+	"io"
+	"mime/multipart"
+	"sort"
+)
+
+// minProgressStep is the fewest bytes between two progress callbacks.
+const minProgressStep = 64 << 10
+
+// UploadOption customizes UploadAndScan.
+type UploadOption func(*uploadOptions)
+
+type uploadOptions struct {
+	progress func(bytesSent, total int64)
+}
+
+// WithProgress reports upload progress to fn as the archive is sent. fn is
+// called roughly every 1% of the archive (at least every 64 KiB), and once
+// more when the whole archive has been sent. It runs on the upload
+// goroutine and should return quickly.
+func WithProgress(fn func(bytesSent, total int64)) UploadOption {
+	return func(o *uploadOptions) { o.progress = fn }
+}
+
+// progressReader reports how much of r has been read.
+type progressReader struct {
+	r     io.Reader
+	total int64
+	sent  int64
+	next  int64
+	step  int64
+	fn    func(bytesSent, total int64)
+}
+
+func newProgressReader(r io.Reader, total int64, fn func(bytesSent, total int64)) *progressReader {
+	step := total / 100
+	if step < minProgressStep {
+		step = minProgressStep
+	}
+	return &progressReader{r: r, total: total, step: step, next: step, fn: fn}
+}
+
+func (p *progressReader) Read(b []byte) (int, error) {
+	n, err := p.r.Read(b)
+	if n > 0 {
+		p.sent += int64(n)
+		if p.sent >= p.next || p.sent == p.total {
+			p.fn(p.sent, p.total)
+			p.next = p.sent + p.step
+		}
+	}
+	return n, err
+}
+
+// writeUploadForm streams a multipart form with the given fields and the
+// archive to w, so large archives are never held in memory.
+func writeUploadForm(w *multipart.Writer, fields map[string]string, filename string, archive io.Reader) error {
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		if err := w.WriteField(k, fields[k]); err != nil {
+			return err
+		}
+	}
+	part, err := w.CreateFormFile("archive", filename)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(part, archive); err != nil {
+		return err
+	}
+	return w.Close()
+}
@@ -0,0 +1,80 @@
+package tavo
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestUploadAndScanStreamsWithProgress(t *testing.T) {
+	archive := bytes.Repeat([]byte("0123456789abcdef"), 1<<16) // 1 MiB
+	path := filepath.Join(t.TempDir(), "repo.tar.gz")
+	if err := os.WriteFile(path, archive, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/scans/upload" {
+			t.Errorf("unexpected %s %s", r.Method, r.URL.Path)
+		}
+		if r.Header.Get("X-API-Key") != "test-key" {
+			t.Error("upload sent without credentials")
+		}
+		if err := r.ParseMultipartForm(2 << 20); err != nil {
+			t.Fatal(err)
+		}
+		if r.FormValue("name") != "nightly" || r.FormValue("depth") != "3" {
+			t.Errorf("form = %v", r.MultipartForm.Value)
+		}
+		f, hdr, err := r.FormFile("archive")
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, _ := io.ReadAll(f)
+		if hdr.Filename != "repo.tar.gz" || !bytes.Equal(got, archive) {
+			t.Errorf("archive %s: %d bytes", hdr.Filename, len(got))
+		}
+		writeJSON(w, http.StatusCreated, map[string]interface{}{"id": "s1"})
+	})
+
+	var calls [][2]int64
+	scan, err := c.Scans().UploadAndScan(context.Background(), path,
+		map[string]interface{}{"name": "nightly", "depth": 3},
+		WithProgress(func(sent, total int64) { calls = append(calls, [2]int64{sent, total}) }))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if scan["id"] != "s1" {
+		t.Fatalf("scan = %v", scan)
+	}
+
+	total := int64(len(archive))
+	if n := len(calls); n < 2 || n > 20 {
+		t.Fatalf("%d progress calls, want a handful", n)
+	}
+	for i, cl := range calls {
+		if cl[1] != total || (i > 0 && cl[0] <= calls[i-1][0]) {
+			t.Fatalf("call %d = %v", i, cl)
+		}
+	}
+	if last := calls[len(calls)-1]; last[0] != total {
+		t.Fatalf("last progress = %v, want %d", last, total)
+	}
+}
+
+func TestUploadAndScanServerError(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "repo.zip")
+	if err := os.WriteFile(path, []byte("zip"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusRequestEntityTooLarge, map[string]interface{}{"message": "archive too large"})
+	})
+	if _, err := c.Scans().UploadAndScan(context.Background(), path, nil); err == nil {
+		t.Fatal("expected an error")
+	}
+}
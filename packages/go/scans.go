@@ -0,0 +1,590 @@
+package tavo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// ScanOperations groups the /scans endpoints.
+type ScanOperations struct {
+	client *Client
+
+	cacheMu sync.Mutex
+	cache   map[string]cachedScan
+}
+
+// Finding is a single issue reported by a scan.
+type Finding struct {
+	ID       string `json:"id"`
+	RuleID   string `json:"rule_id"`
+	Severity string `json:"severity"`
+	File     string `json:"file"`
+	Line     int    `json:"line"`
+	Column   int    `json:"column,omitempty"`
+	Message  string `json:"message"`
+	Category string `json:"category,omitempty"`
+	// Code is the flagged source line, when the server reports it without
+	// a snippet.
+	Code string `json:"code,omitempty"`
+
+	// Snippet is the source around the finding. It is nil unless the server
+	// includes it; request it with WithSnippetContext.
+	Snippet *Snippet `json:"snippet,omitempty"`
+}
+
+// Snippet is a range of source lines surrounding a finding.
+type Snippet struct {
+	// StartLine is the 1-based line number of Lines[0].
+	StartLine int      `json:"start_line"`
+	Lines     []string `json:"lines"`
+}
+
+// EndLine returns the line number of the snippet's last line.
+func (s *Snippet) EndLine() int {
+	return s.StartLine + len(s.Lines) - 1
+}
+
+// ResultFilter narrows the findings returned for a scan.
+type ResultFilter struct {
+	Severities []Severity
+	RuleID     string
+	File       string
+	Limit      int
+	Offset     int
+
+	// SortBy orders the findings by "severity", "rule_id", "file" or
+	// "line", and Order is "asc" (the default) or "desc". See
+	// IterateFindings for how servers that ignore sorting are handled.
+	SortBy string
+	Order  string
+}
+
+func (f ResultFilter) params() map[string]interface{} {
+	params := map[string]interface{}{}
+	if len(f.Severities) > 0 {
+		names := make([]string, len(f.Severities))
+		for i, s := range f.Severities {
+			names[i] = s.String()
+		}
+		params["severity"] = names
+	}
+	if f.RuleID != "" {
+		params["rule_id"] = f.RuleID
+	}
+	if f.File != "" {
+		params["file"] = f.File
+	}
+	if f.Limit > 0 {
+		params["limit"] = f.Limit
+	}
+	if f.Offset > 0 {
+		params["offset"] = f.Offset
+	}
+	if f.SortBy != "" {
+		params["sort"] = f.SortBy
+		if f.Order != "" {
+			params["order"] = f.Order
+		}
+	}
+	return params
+}
+
+// CreateScan starts a new scan.
+func (s *ScanOperations) CreateScan(ctx context.Context, scanData map[string]interface{}) (map[string]interface{}, error) {
+	return s.client.makeRequest(ctx, http.MethodPost, "/scans", scanData, nil)
+}
+
+// CreateScanFromReader is CreateScan with the JSON scan definition read
+// from r, which is streamed to the server rather than buffered. Use it for
+// definitions too large to hold in memory twice. The request is not
+// retried, and fails with ErrBodyTooLarge past Config.MaxBodyBytes.
+func (s *ScanOperations) CreateScanFromReader(ctx context.Context, r io.Reader) (map[string]interface{}, error) {
+	return s.client.makeRequest(ctx, http.MethodPost, "/scans", r, nil)
+}
+
+// scanServerFields are set by the server and dropped when cloning a scan.
+var scanServerFields = []string{"id", "status", "created_at", "results"}
+
+// CloneScan creates a new scan with the configuration of scanID. Server
+// managed fields are dropped, overrides are applied on top, and the newly
+// created scan is returned. Overrides may set a key to nil to remove it.
+func (s *ScanOperations) CloneScan(ctx context.Context, scanID string, overrides map[string]interface{}) (map[string]interface{}, error) {
+	scan, err := s.GetScan(ctx, scanID)
+	if err != nil {
+		return nil, err
+	}
+	data := copyParams(scan)
+	for _, k := range scanServerFields {
+		delete(data, k)
+	}
+	for k, v := range overrides {
+		if v == nil {
+			delete(data, k)
+			continue
+		}
+		data[k] = v
+	}
+	return s.CreateScan(ctx, data)
+}
+
+// GetScan fetches a scan by ID.
+func (s *ScanOperations) GetScan(ctx context.Context, scanID string) (map[string]interface{}, error) {
+	return s.client.makeRequest(ctx, http.MethodGet, "/scans/"+scanID, nil, nil)
+}
+
+// consistentReadAttempts bounds how often GetScanConsistent retries a 404.
+const consistentReadAttempts = 5
+
+// GetScanConsistent fetches a scan that may have just been written. It asks
+// the server for a consistent read and, in case a replica still answers
+// 404, retries up to consistentReadAttempts times, RetryWait apart. Use it
+// for read-after-write; GetScan is cheaper everywhere else.
+func (s *ScanOperations) GetScanConsistent(ctx context.Context, scanID string) (map[string]interface{}, error) {
+	params := map[string]interface{}{"consistent": true}
+	var err error
+	for attempt := 1; ; attempt++ {
+		var scan map[string]interface{}
+		scan, err = s.client.makeRequest(ctx, http.MethodGet, "/scans/"+scanID, nil, params)
+		var tErr *TavoError
+		if err == nil || !errors.As(err, &tErr) || tErr.StatusCode != http.StatusNotFound || attempt == consistentReadAttempts {
+			return scan, err
+		}
+		if err := sleepContext(ctx, s.client.config.RetryWait); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// ListScans lists scans. Supported params include status, limit and offset.
+func (s *ScanOperations) ListScans(ctx context.Context, params map[string]interface{}) (map[string]interface{}, error) {
+	return s.client.makeRequest(ctx, http.MethodGet, "/scans", nil, params)
+}
+
+// ListScansPaged lists one page of scans with its pagination metadata.
+func (s *ScanOperations) ListScansPaged(ctx context.Context, params map[string]interface{}) (*Page[map[string]interface{}], error) {
+	resp, err := s.ListScans(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	return decodePage[map[string]interface{}](resp, params)
+}
+
+// UpdateScan replaces a scan's mutable fields.
+func (s *ScanOperations) UpdateScan(ctx context.Context, scanID string, data map[string]interface{}) (map[string]interface{}, error) {
+	return s.client.makeRequest(ctx, http.MethodPut, "/scans/"+scanID, data, nil)
+}
+
+// UpdateScanWithMask updates only the listed fields of a scan. The mask is
+// sent as a comma-separated update_mask query parameter alongside data, so
+// fields omitted from data but not named in fields are left untouched.
+func (s *ScanOperations) UpdateScanWithMask(ctx context.Context, scanID string, data map[string]interface{}, fields []string) (map[string]interface{}, error) {
+	if len(fields) == 0 {
+		return nil, errors.New("tavo: update mask must name at least one field")
+	}
+	params := map[string]interface{}{"update_mask": strings.Join(fields, ",")}
+	return s.client.makeRequest(ctx, http.MethodPut, "/scans/"+scanID, data, params)
+}
+
+// DeleteScan deletes a scan.
+func (s *ScanOperations) DeleteScan(ctx context.Context, scanID string) error {
+	_, err := s.client.makeRequest(ctx, http.MethodDelete, "/scans/"+scanID, nil, nil)
+	return err
+}
+
+// DeleteOptions controls DeleteScanWithOptions.
+type DeleteOptions struct {
+	// Cascade also deletes the scan's results and reports.
+	Cascade bool
+	// Force deletes the scan even if it is still running.
+	Force bool
+}
+
+// DeleteSummary reports what a delete removed.
+type DeleteSummary struct {
+	ScanID string `json:"scan_id"`
+	// Deleted counts removed resources by kind, for example "scans",
+	// "results" and "reports".
+	Deleted map[string]int `json:"deleted"`
+}
+
+// DeleteScanWithOptions deletes a scan and, with Cascade, its results and
+// reports, returning a summary of what was removed. When the server sends
+// no summary the scan itself is reported as the only deletion.
+func (s *ScanOperations) DeleteScanWithOptions(ctx context.Context, scanID string, opts DeleteOptions) (*DeleteSummary, error) {
+	params := map[string]interface{}{}
+	if opts.Cascade {
+		params["cascade"] = true
+	}
+	if opts.Force {
+		params["force"] = true
+	}
+	resp, err := s.client.makeRequest(ctx, http.MethodDelete, "/scans/"+scanID, nil, params)
+	if err != nil {
+		return nil, err
+	}
+	s.forgetScan(scanID)
+
+	summary := &DeleteSummary{}
+	if err := decodeMap(resp, summary); err != nil {
+		return nil, err
+	}
+	if summary.ScanID == "" {
+		summary.ScanID = scanID
+	}
+	if len(summary.Deleted) == 0 {
+		summary.Deleted = map[string]int{"scans": 1}
+	}
+	return summary, nil
+}
+
+// GetScanResults fetches a page of a scan's findings.
+func (s *ScanOperations) GetScanResults(ctx context.Context, scanID string, params map[string]interface{}) (map[string]interface{}, error) {
+	return s.client.makeRequest(ctx, http.MethodGet, "/scans/"+scanID+"/results", nil, params)
+}
+
+// GetScanStatus fetches a scan's current status and progress.
+func (s *ScanOperations) GetScanStatus(ctx context.Context, scanID string) (map[string]interface{}, error) {
+	return s.client.makeRequest(ctx, http.MethodGet, "/scans/"+scanID+"/status", nil, nil)
+}
+
+// StopScan stops a running scan.
+func (s *ScanOperations) StopScan(ctx context.Context, scanID string) (map[string]interface{}, error) {
+	return s.client.makeRequest(ctx, http.MethodPost, "/scans/"+scanID+"/stop", nil, nil)
+}
+
+// GetFindings fetches a page of typed findings matching filter.
+func (s *ScanOperations) GetFindings(ctx context.Context, scanID string, filter ResultFilter) ([]Finding, int, error) {
+	findings, total, _, err := s.findingsPage(ctx, scanID, filter)
+	return findings, total, err
+}
+
+// findingsPage is GetFindings that also reports whether the server
+// applied filter.SortBy, which it signals by echoing it as "sort".
+func (s *ScanOperations) findingsPage(ctx context.Context, scanID string, filter ResultFilter) ([]Finding, int, bool, error) {
+	resp, err := s.GetScanResults(ctx, scanID, filter.params())
+	if err != nil {
+		return nil, 0, false, err
+	}
+	raw, total := pageItems(resp, filter.Offset)
+	findings, err := decodeFindings(raw)
+	if err != nil {
+		return nil, 0, false, err
+	}
+	sorted := filter.SortBy != "" && resp["sort"] == filter.SortBy
+	return findings, total, sorted, nil
+}
+
+// IterateScans walks every scan matching params.
+func (s *ScanOperations) IterateScans(ctx context.Context, params map[string]interface{}) *Iterator[map[string]interface{}] {
+	return newIterator(ctx, func(ctx context.Context, offset int) ([]map[string]interface{}, int, error) {
+		p := copyParams(params)
+		p["offset"] = offset
+		if _, ok := p["limit"]; !ok {
+			p["limit"] = DefaultPageSize
+		}
+		resp, err := s.ListScans(ctx, p)
+		if err != nil {
+			return nil, 0, err
+		}
+		items, total := pageItems(resp, offset)
+		return items, total, nil
+	})
+}
+
+// IterateScansCursor walks every scan matching params on servers that
+// paginate scans with an opaque next_cursor instead of offset and total.
+// Each response's next_cursor is sent back as the cursor param, and
+// iteration stops when it is empty.
+func (s *ScanOperations) IterateScansCursor(ctx context.Context, params map[string]interface{}) *Iterator[map[string]interface{}] {
+	return newCursorIterator(ctx, func(ctx context.Context, cursor string) ([]map[string]interface{}, string, error) {
+		p := copyParams(params)
+		if cursor != "" {
+			p["cursor"] = cursor
+		}
+		if _, ok := p["limit"]; !ok {
+			p["limit"] = DefaultPageSize
+		}
+		resp, err := s.ListScans(ctx, p)
+		if err != nil {
+			return nil, "", err
+		}
+		items, _ := pageItems(resp, 0)
+		next, _ := resp["next_cursor"].(string)
+		return items, next, nil
+	})
+}
+
+// IterateFindings walks every finding of a scan matching filter. The
+// filter's Offset is the starting point.
+//
+// With SortBy set, the sort is requested from the server. A server that
+// does not echo the sort key back is assumed to ignore it: the iterator
+// then loads every remaining finding into memory and sorts them itself,
+// which for large scans is memory-heavy. Local sorting breaks ties by rule
+// ID, keeping the order stable between runs.
+func (s *ScanOperations) IterateFindings(ctx context.Context, scanID string, filter ResultFilter) *Iterator[Finding] {
+	if filter.Limit <= 0 {
+		filter.Limit = DefaultPageSize
+	}
+	start := filter.Offset
+	fetch := func(ctx context.Context, offset int) ([]Finding, int, bool, error) {
+		f := filter
+		f.Offset = start + offset
+		findings, total, sorted, err := s.findingsPage(ctx, scanID, f)
+		return findings, total - start, sorted, err
+	}
+	if filter.SortBy == "" {
+		return newIterator(ctx, func(ctx context.Context, offset int) ([]Finding, int, error) {
+			findings, total, _, err := fetch(ctx, offset)
+			return findings, total, err
+		})
+	}
+	return newSortedFindingIterator(ctx, filter.SortBy, filter.Order, fetch)
+}
+
+// WaitForScan polls the scan status every pollInterval (DefaultPollInterval
+// when zero or negative) until the scan completes, fails or is cancelled,
+// and returns the final status.
+func (s *ScanOperations) WaitForScan(ctx context.Context, scanID string, pollInterval time.Duration) (map[string]interface{}, error) {
+	return s.WaitForScanWithOptions(ctx, scanID, WaitForScanOptions{Interval: pollInterval})
+}
+
+// DefaultStopTimeout bounds the StopScan call WaitForScanWithOptions makes
+// when StopOnCancel is set.
+const DefaultStopTimeout = 10 * time.Second
+
+// Poll defaults for WaitForScanWithOptions; DefaultMaxPollInterval applies
+// only when Backoff is set. DefaultPollInterval also replaces a zero
+// interval passed to the other polling helpers, such as
+// GenerateReportAndWait.
+const (
+	DefaultPollInterval    = time.Second
+	DefaultMaxPollInterval = 30 * time.Second
+)
+
+// backoffResetProgress is the progress gain, in percentage points, that
+// resets a backed-off poll interval to its base.
+const backoffResetProgress = 10
+
+// WaitForScanOptions configures WaitForScanWithOptions.
+type WaitForScanOptions struct {
+	// Interval is the delay between status polls, DefaultPollInterval when
+	// zero. With Backoff it is the first delay.
+	Interval time.Duration
+	// Backoff doubles the delay after every poll, up to MaxInterval
+	// (DefaultMaxPollInterval when zero), so quick scans are noticed
+	// quickly without hammering the API during slow ones. The delay drops
+	// back to Interval whenever the scan's progress has advanced by 10
+	// points or more since the last reset.
+	Backoff     bool
+	MaxInterval time.Duration
+	// StopOnCancel stops the scan server-side when ctx is cancelled, so
+	// abandoned scans do not keep consuming quota.
+	StopOnCancel bool
+	// StopTimeout bounds the StopScan call; zero means DefaultStopTimeout.
+	StopTimeout time.Duration
+}
+
+// WaitForScanWithOptions polls a scan's status until it reaches a terminal
+// state and returns the final status.
+func (s *ScanOperations) WaitForScanWithOptions(ctx context.Context, scanID string, opts WaitForScanOptions) (map[string]interface{}, error) {
+	poll := newPollSchedule(opts)
+	for {
+		status, err := s.GetScanStatus(ctx, scanID)
+		if err == nil && isTerminalScanStatus(status["status"]) {
+			return status, nil
+		}
+		if err == nil {
+			progress, _ := status["progress"].(float64)
+			err = sleepContext(ctx, poll.next(progress))
+		}
+		if err != nil {
+			if ctx.Err() != nil && opts.StopOnCancel {
+				return nil, s.stopAbandonedScan(scanID, opts.StopTimeout, ctx.Err())
+			}
+			return nil, err
+		}
+	}
+}
+
+// pollSchedule yields the delays between WaitForScanWithOptions polls.
+type pollSchedule struct {
+	base, max, cur time.Duration
+	backoff        bool
+	progress       float64
+}
+
+func newPollSchedule(opts WaitForScanOptions) *pollSchedule {
+	p := &pollSchedule{base: opts.Interval, max: opts.MaxInterval, backoff: opts.Backoff}
+	if p.base <= 0 {
+		p.base = DefaultPollInterval
+	}
+	if p.backoff {
+		if p.max <= 0 {
+			p.max = DefaultMaxPollInterval
+		}
+		if p.max < p.base {
+			p.max = p.base
+		}
+	}
+	return p
+}
+
+// next returns the delay before the next poll, given the progress the
+// last poll reported.
+func (p *pollSchedule) next(progress float64) time.Duration {
+	if !p.backoff {
+		return p.base
+	}
+	switch {
+	case p.cur == 0 || progress-p.progress >= backoffResetProgress:
+		p.cur = p.base
+		p.progress = progress
+	default:
+		p.cur *= 2
+		if p.cur > p.max {
+			p.cur = p.max
+		}
+	}
+	return p.cur
+}
+
+// stopAbandonedScan stops scanID with a fresh context, since the caller's
+// is already done, and returns cause joined with any stop failure.
+func (s *ScanOperations) stopAbandonedScan(scanID string, timeout time.Duration, cause error) error {
+	if timeout <= 0 {
+		timeout = DefaultStopTimeout
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	if _, err := s.StopScan(ctx, scanID); err != nil {
+		return errors.Join(cause, fmt.Errorf("tavo: stopping scan %s: %w", scanID, err))
+	}
+	return cause
+}
+
+// UploadAndScan uploads a source archive and starts a scan of it. The
+// scanData values are sent as multipart form fields. The archive is
+// streamed rather than buffered; use WithProgress to follow the upload.
+func (s *ScanOperations) UploadAndScan(ctx context.Context, archivePath string, scanData map[string]interface{}, opts ...UploadOption) (map[string]interface{}, error) {
+	var o uploadOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	f, err := os.Open(archivePath)
+	if err != nil {
+		return nil, fmt.Errorf("tavo: opening archive: %w", err)
+	}
+	defer f.Close()
+	var archive io.Reader = f
+	if o.progress != nil {
+		info, err := f.Stat()
+		if err != nil {
+			return nil, fmt.Errorf("tavo: opening archive: %w", err)
+		}
+		archive = newProgressReader(f, info.Size(), o.progress)
+	}
+
+	form := make(map[string]string, len(scanData))
+	for k, v := range scanData {
+		form[k] = fmt.Sprint(v)
+	}
+	pr, pw := io.Pipe()
+	// Closing the read side unblocks the writer if the request ends early.
+	defer pr.Close()
+	mw := multipart.NewWriter(pw)
+	go func() {
+		pw.CloseWithError(writeUploadForm(mw, form, filepath.Base(archivePath), archive))
+	}()
+
+	r := s.client.http.R().
+		SetContext(ctx).
+		SetHeader("Content-Type", mw.FormDataContentType())
+	if err := s.client.applyCredentials(r); err != nil {
+		return nil, err
+	}
+	req := &apiRequest{method: http.MethodPost, path: "/scans/upload"}
+	resp, err := s.client.sendAttempt(ctx, req, 0, func() (*resty.Response, error) {
+		return s.client.doStreamed(ctx, req.method, req.path, r, pr, false)
+	})
+	if te, ok := err.(*TavoError); ok {
+		return nil, te
+	}
+	if err != nil {
+		return nil, fmt.Errorf("tavo: uploading archive: %w", err)
+	}
+	return checkResponse(resp)
+}
+
+func isTerminalScanStatus(status interface{}) bool {
+	switch status {
+	case "completed", "failed", "cancelled":
+		return true
+	}
+	return false
+}
+
+func decodeFindings(raw []map[string]interface{}) ([]Finding, error) {
+	findings := make([]Finding, 0, len(raw))
+	for _, m := range raw {
+		var f Finding
+		if err := decodeMap(m, &f); err != nil {
+			return nil, err
+		}
+		findings = append(findings, f)
+	}
+	return findings, nil
+}
+
+// decodeMap converts a decoded JSON object into a typed struct.
+func decodeMap(m map[string]interface{}, v interface{}) error {
+	data, err := json.Marshal(m)
+	if err != nil {
+		return fmt.Errorf("tavo: re-encoding response: %w", err)
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("tavo: decoding response: %w", err)
+	}
+	return nil
+}
+
+// extraFields returns the entries of m that do not correspond to a JSON
+// field of the struct type of v, or nil when there are none. Typed models
+// keep them so fields added by the API are not lost.
+func extraFields(m map[string]interface{}, v interface{}) map[string]interface{} {
+	known := make(map[string]bool)
+	t := reflect.TypeOf(v)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if name != "" && name != "-" {
+			known[name] = true
+		}
+	}
+	var extra map[string]interface{}
+	for k, val := range m {
+		if known[k] {
+			continue
+		}
+		if extra == nil {
+			extra = make(map[string]interface{})
+		}
+		extra[k] = val
+	}
+	return extra
+}
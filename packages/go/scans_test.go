@@ -0,0 +1,301 @@
+package tavo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestGetFindingsWithSnippetContext(t *testing.T) {
+	c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("snippet_context"); got != "2" {
+			t.Errorf("snippet_context = %q", got)
+		}
+		if got := r.URL.Query().Get("severity"); got != "high" {
+			t.Errorf("severity = %q", got)
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"items": []map[string]interface{}{{
+				"rule_id": "sqli", "file": "db.go", "line": 12, "severity": "high",
+				"snippet": map[string]interface{}{
+					"start_line": 10,
+					"lines":      []string{"a", "b", "query(x)", "d", "e"},
+				},
+			}},
+			"total": 1,
+		})
+	})
+
+	ctx := WithRequestOptions(context.Background(), WithSnippetContext(2))
+	findings, total, err := c.Scans().GetFindings(ctx, "s1", ResultFilter{Severities: []Severity{SeverityHigh}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total != 1 || len(findings) != 1 {
+		t.Fatalf("findings = %+v, total = %d", findings, total)
+	}
+	snip := findings[0].Snippet
+	if snip == nil || snip.StartLine != 10 || snip.EndLine() != 14 || snip.Lines[2] != "query(x)" {
+		t.Fatalf("snippet = %+v", snip)
+	}
+}
+
+func TestUpdateScanWithMask(t *testing.T) {
+	c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut || r.URL.Path != "/scans/s1" {
+			t.Errorf("unexpected %s %s", r.Method, r.URL.Path)
+		}
+		if got := r.URL.Query().Get("update_mask"); got != "name,tags" {
+			t.Errorf("update_mask = %q", got)
+		}
+		var body map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&body)
+		if body["name"] != "nightly" {
+			t.Errorf("body = %v", body)
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{"id": "s1", "name": "nightly"})
+	})
+
+	scan, err := c.Scans().UpdateScanWithMask(context.Background(), "s1",
+		map[string]interface{}{"name": "nightly"}, []string{"name", "tags"})
+	if err != nil || scan["name"] != "nightly" {
+		t.Fatalf("scan = %v, err = %v", scan, err)
+	}
+
+	if _, err := c.Scans().UpdateScanWithMask(context.Background(), "s1", nil, nil); err == nil {
+		t.Fatal("expected error for empty mask")
+	}
+}
+
+func TestGetScanConsistentRetriesNotFound(t *testing.T) {
+	calls := 0
+	c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if r.URL.Query().Get("consistent") != "true" {
+			t.Errorf("consistent = %q", r.URL.Query().Get("consistent"))
+		}
+		if calls < 3 {
+			writeJSON(w, http.StatusNotFound, map[string]interface{}{"message": "not found"})
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{"id": "s1"})
+	})
+
+	scan, err := c.Scans().GetScanConsistent(context.Background(), "s1")
+	if err != nil || scan["id"] != "s1" || calls != 3 {
+		t.Fatalf("scan = %v, err = %v, calls = %d", scan, err, calls)
+	}
+}
+
+func TestGetScanConsistentGivesUp(t *testing.T) {
+	calls := 0
+	c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		writeJSON(w, http.StatusNotFound, map[string]interface{}{"message": "not found"})
+	})
+
+	_, err := c.Scans().GetScanConsistent(context.Background(), "s1")
+	if err == nil || calls != consistentReadAttempts {
+		t.Fatalf("err = %v, calls = %d", err, calls)
+	}
+}
+
+func TestWaitForScanStopOnCancel(t *testing.T) {
+	stopped := make(chan struct{}, 1)
+	ctx, cancel := context.WithCancel(context.Background())
+	c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/scans/s1/status":
+			cancel()
+			writeJSON(w, http.StatusOK, map[string]interface{}{"status": "running"})
+		case "/scans/s1/stop":
+			stopped <- struct{}{}
+			writeJSON(w, http.StatusOK, map[string]interface{}{"status": "cancelled"})
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	})
+
+	_, err := c.Scans().WaitForScanWithOptions(ctx, "s1", WaitForScanOptions{Interval: time.Hour, StopOnCancel: true})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("err = %v, want context.Canceled", err)
+	}
+	select {
+	case <-stopped:
+	default:
+		t.Fatal("scan was not stopped")
+	}
+}
+
+func TestWaitForScanWithoutStopOnCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/scans/s1/status" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		cancel()
+		writeJSON(w, http.StatusOK, map[string]interface{}{"status": "running"})
+	})
+	if _, err := c.Scans().WaitForScan(ctx, "s1", time.Hour); !errors.Is(err, context.Canceled) {
+		t.Fatalf("err = %v", err)
+	}
+}
+
+func TestWaitForScanStopFailureIsReported(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/scans/s1/stop" {
+			writeJSON(w, http.StatusConflict, map[string]interface{}{"message": "already finished"})
+			return
+		}
+		cancel()
+		writeJSON(w, http.StatusOK, map[string]interface{}{"status": "running"})
+	})
+	_, err := c.Scans().WaitForScanWithOptions(ctx, "s1", WaitForScanOptions{Interval: time.Hour, StopOnCancel: true})
+	var tErr *TavoError
+	if !errors.Is(err, context.Canceled) || !errors.As(err, &tErr) || tErr.StatusCode != http.StatusConflict {
+		t.Fatalf("err = %v", err)
+	}
+}
+
+func TestDeleteScanWithOptions(t *testing.T) {
+	c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if r.Method != http.MethodDelete || q.Get("cascade") != "true" || q.Get("force") != "true" {
+			t.Errorf("unexpected %s %s", r.Method, r.URL)
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"scan_id": "s1",
+			"deleted": map[string]int{"scans": 1, "results": 42, "reports": 2},
+		})
+	})
+
+	summary, err := c.Scans().DeleteScanWithOptions(context.Background(), "s1", DeleteOptions{Cascade: true, Force: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if summary.ScanID != "s1" || summary.Deleted["results"] != 42 || summary.Deleted["reports"] != 2 {
+		t.Fatalf("summary = %+v", summary)
+	}
+}
+
+func TestDeleteScanWithOptionsNoContent(t *testing.T) {
+	c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if len(r.URL.Query()) != 0 {
+			t.Errorf("unexpected params %v", r.URL.Query())
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	summary, err := c.Scans().DeleteScanWithOptions(context.Background(), "s1", DeleteOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if summary.ScanID != "s1" || len(summary.Deleted) != 1 || summary.Deleted["scans"] != 1 {
+		t.Fatalf("summary = %+v", summary)
+	}
+}
+
+func TestCloneScan(t *testing.T) {
+	var created map[string]interface{}
+	c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/scans/s1":
+			writeJSON(w, http.StatusOK, map[string]interface{}{
+				"id":         "s1",
+				"status":     "completed",
+				"created_at": "2026-01-01T00:00:00Z",
+				"results":    map[string]interface{}{"total": 3},
+				"name":       "nightly",
+				"target":     "https://github.com/acme/app",
+				"branch":     "main",
+				"rules":      []interface{}{"r1"},
+			})
+		case r.Method == http.MethodPost && r.URL.Path == "/scans":
+			if err := json.NewDecoder(r.Body).Decode(&created); err != nil {
+				t.Error(err)
+			}
+			writeJSON(w, http.StatusCreated, map[string]interface{}{"id": "s2", "status": "queued"})
+		default:
+			t.Errorf("unexpected %s %s", r.Method, r.URL.Path)
+		}
+	})
+
+	scan, err := c.Scans().CloneScan(context.Background(), "s1", map[string]interface{}{
+		"target": "https://github.com/acme/api",
+		"branch": nil,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if scan["id"] != "s2" {
+		t.Fatalf("returned %v, want the new scan", scan)
+	}
+	want := map[string]interface{}{
+		"name":   "nightly",
+		"target": "https://github.com/acme/api",
+		"rules":  []interface{}{"r1"},
+	}
+	if !reflect.DeepEqual(created, want) {
+		t.Fatalf("created with %v, want %v", created, want)
+	}
+}
+
+func TestPollScheduleBackoff(t *testing.T) {
+	p := newPollSchedule(WaitForScanOptions{Backoff: true, Interval: time.Second, MaxInterval: 5 * time.Second})
+	var got []time.Duration
+	for _, progress := range []float64{0, 2, 4, 6, 8, 25, 26, 27} {
+		got = append(got, p.next(progress))
+	}
+	want := []time.Duration{1, 2, 4, 5, 5, 1, 2, 4}
+	for i := range want {
+		if got[i] != want[i]*time.Second {
+			t.Fatalf("delays = %v, want %v seconds", got, want)
+		}
+	}
+}
+
+func TestPollScheduleDefaults(t *testing.T) {
+	p := newPollSchedule(WaitForScanOptions{Backoff: true})
+	if d := p.next(0); d != DefaultPollInterval {
+		t.Fatalf("first delay = %v", d)
+	}
+	for i := 0; i < 10; i++ {
+		p.next(0)
+	}
+	if d := p.next(0); d != DefaultMaxPollInterval {
+		t.Fatalf("capped delay = %v", d)
+	}
+	fixed := newPollSchedule(WaitForScanOptions{Interval: 3 * time.Millisecond})
+	if fixed.next(0) != 3*time.Millisecond || fixed.next(50) != 3*time.Millisecond {
+		t.Fatal("fixed interval changed without Backoff")
+	}
+	if d := newPollSchedule(WaitForScanOptions{}).next(0); d != DefaultPollInterval {
+		t.Fatalf("zero interval without Backoff = %v, want DefaultPollInterval", d)
+	}
+}
+
+func TestWaitForScanWithBackoff(t *testing.T) {
+	polls := 0
+	c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		polls++
+		status := "running"
+		if polls == 4 {
+			status = "completed"
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{"status": status, "progress": polls * 5})
+	})
+	status, err := c.Scans().WaitForScanWithOptions(context.Background(), "s1", WaitForScanOptions{
+		Backoff: true, Interval: time.Millisecond, MaxInterval: 2 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status["status"] != "completed" || polls != 4 {
+		t.Fatalf("status = %v after %d polls", status, polls)
+	}
+}
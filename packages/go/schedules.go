@@ -0,0 +1,58 @@
+package tavo
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/robfig/cron/v3"
+)
+
+// cronParser accepts standard five-field cron expressions and descriptors
+// such as "@daily".
+var cronParser = cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow | cron.Descriptor)
+
+// ValidateCron reports whether expr is a valid five-field cron expression
+// or descriptor.
+func ValidateCron(expr string) error {
+	if _, err := cronParser.Parse(expr); err != nil {
+		return fmt.Errorf("tavo: invalid cron expression %q: %w", expr, err)
+	}
+	return nil
+}
+
+// ScheduleScan creates a recurring scan that runs scanData on the cron
+// schedule. The expression is validated before anything is sent. The
+// returned schedule includes its next_run time.
+func (s *ScanOperations) ScheduleScan(ctx context.Context, scanData map[string]interface{}, cronExpr string) (map[string]interface{}, error) {
+	if err := ValidateCron(cronExpr); err != nil {
+		return nil, err
+	}
+	data := copyParams(scanData)
+	data["cron"] = cronExpr
+	return s.client.makeRequest(ctx, http.MethodPost, "/scans/schedules", data, nil)
+}
+
+// ListSchedules lists scan schedules.
+func (s *ScanOperations) ListSchedules(ctx context.Context, params map[string]interface{}) (map[string]interface{}, error) {
+	return s.client.makeRequest(ctx, http.MethodGet, "/scans/schedules", nil, params)
+}
+
+// UpdateSchedule updates a scan schedule. A "cron" value in data is
+// validated before anything is sent.
+func (s *ScanOperations) UpdateSchedule(ctx context.Context, scheduleID string, data map[string]interface{}) (map[string]interface{}, error) {
+	if v, ok := data["cron"]; ok {
+		expr, _ := v.(string)
+		if err := ValidateCron(expr); err != nil {
+			return nil, err
+		}
+	}
+	return s.client.makeRequest(ctx, http.MethodPut, "/scans/schedules/"+scheduleID, data, nil)
+}
+
+// DeleteSchedule deletes a scan schedule. Scans it already started are not
+// affected.
+func (s *ScanOperations) DeleteSchedule(ctx context.Context, scheduleID string) error {
+	_, err := s.client.makeRequest(ctx, http.MethodDelete, "/scans/schedules/"+scheduleID, nil, nil)
+	return err
+}
@@ -0,0 +1,61 @@
+package tavo
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func TestScheduleScan(t *testing.T) {
+	c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/scans/schedules":
+			var body map[string]interface{}
+			json.NewDecoder(r.Body).Decode(&body)
+			if body["cron"] != "0 2 * * *" || body["target"] != "repo" {
+				t.Errorf("body = %v", body)
+			}
+			writeJSON(w, http.StatusCreated, map[string]interface{}{"id": "sch1", "next_run": "2025-01-02T02:00:00Z"})
+		case r.Method == http.MethodPut && r.URL.Path == "/scans/schedules/sch1":
+			writeJSON(w, http.StatusOK, map[string]interface{}{"id": "sch1"})
+		case r.Method == http.MethodDelete && r.URL.Path == "/scans/schedules/sch1":
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == http.MethodGet && r.URL.Path == "/scans/schedules":
+			writeJSON(w, http.StatusOK, map[string]interface{}{"items": []interface{}{}, "total": 0})
+		default:
+			t.Errorf("unexpected %s %s", r.Method, r.URL.Path)
+		}
+	})
+	ctx := context.Background()
+	scans := c.Scans()
+
+	sched, err := scans.ScheduleScan(ctx, map[string]interface{}{"target": "repo"}, "0 2 * * *")
+	if err != nil || sched["next_run"] == nil {
+		t.Fatalf("schedule = %v, err = %v", sched, err)
+	}
+	if _, err := scans.UpdateSchedule(ctx, "sch1", map[string]interface{}{"cron": "@weekly"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := scans.ListSchedules(ctx, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := scans.DeleteSchedule(ctx, "sch1"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestScheduleScanRejectsBadCron(t *testing.T) {
+	c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("request sent for invalid cron: %s %s", r.Method, r.URL.Path)
+	})
+	ctx := context.Background()
+	for _, expr := range []string{"", "0 2 * *", "61 * * * *", "0 2 * * mon-fry", "* * * * * *"} {
+		if _, err := c.Scans().ScheduleScan(ctx, nil, expr); err == nil {
+			t.Errorf("ScheduleScan accepted %q", expr)
+		}
+	}
+	if _, err := c.Scans().UpdateSchedule(ctx, "sch1", map[string]interface{}{"cron": 5}); err == nil {
+		t.Error("UpdateSchedule accepted a non-string cron")
+	}
+}
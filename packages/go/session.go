@@ -0,0 +1,22 @@
+package tavo
+
+import "sync"
+
+// sessionState holds the client's current session token, which Logout and
+// RefreshSession replace while requests may be in flight.
+type sessionState struct {
+	mu    sync.RWMutex
+	token string
+}
+
+func (s *sessionState) get() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.token
+}
+
+func (s *sessionState) set(token string) {
+	s.mu.Lock()
+	s.token = token
+	s.mu.Unlock()
+}
@@ -0,0 +1,75 @@
+package tavo
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Severity is a finding severity. Severities compare in order of
+// seriousness, so SeverityHigh > SeverityMedium.
+type Severity int
+
+// Severities from least to most serious. SeverityUnknown is the zero
+// value and ranks below SeverityInfo.
+const (
+	SeverityUnknown Severity = iota
+	SeverityInfo
+	SeverityLow
+	SeverityMedium
+	SeverityHigh
+	SeverityCritical
+)
+
+var severityNames = [...]string{
+	SeverityUnknown:  "unknown",
+	SeverityInfo:     "info",
+	SeverityLow:      "low",
+	SeverityMedium:   "medium",
+	SeverityHigh:     "high",
+	SeverityCritical: "critical",
+}
+
+// ParseSeverity parses a severity name as used by the API, ignoring case
+// and surrounding space.
+func ParseSeverity(s string) (Severity, error) {
+	name := strings.ToLower(strings.TrimSpace(s))
+	for sev := SeverityInfo; sev <= SeverityCritical; sev++ {
+		if severityNames[sev] == name {
+			return sev, nil
+		}
+	}
+	return SeverityUnknown, fmt.Errorf("tavo: unknown severity %q (want info, low, medium, high or critical)", s)
+}
+
+// String returns the API name of the severity, such as "high".
+func (s Severity) String() string {
+	if s < SeverityUnknown || s > SeverityCritical {
+		return fmt.Sprintf("Severity(%d)", int(s))
+	}
+	return severityNames[s]
+}
+
+// MarshalText encodes the severity as its API name.
+func (s Severity) MarshalText() ([]byte, error) {
+	if s <= SeverityUnknown || s > SeverityCritical {
+		return nil, fmt.Errorf("tavo: cannot encode severity %s", s)
+	}
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText decodes an API severity name.
+func (s *Severity) UnmarshalText(text []byte) error {
+	sev, err := ParseSeverity(string(text))
+	if err != nil {
+		return err
+	}
+	*s = sev
+	return nil
+}
+
+// SeverityLevel returns the finding's severity as a Severity, or
+// SeverityUnknown when the server sent a name ParseSeverity does not know.
+func (f Finding) SeverityLevel() Severity {
+	sev, _ := ParseSeverity(f.Severity)
+	return sev
+}
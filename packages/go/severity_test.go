@@ -0,0 +1,60 @@
+package tavo
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestParseSeverity(t *testing.T) {
+	for in, want := range map[string]Severity{
+		"info":     SeverityInfo,
+		"LOW":      SeverityLow,
+		" Medium ": SeverityMedium,
+		"high":     SeverityHigh,
+		"critical": SeverityCritical,
+	} {
+		got, err := ParseSeverity(in)
+		if err != nil || got != want {
+			t.Errorf("ParseSeverity(%q) = %v, %v; want %v", in, got, err, want)
+		}
+	}
+	for _, in := range []string{"", "crit", "unknown"} {
+		if _, err := ParseSeverity(in); err == nil {
+			t.Errorf("ParseSeverity(%q) succeeded", in)
+		}
+	}
+}
+
+func TestSeverityOrderAndString(t *testing.T) {
+	order := []Severity{SeverityUnknown, SeverityInfo, SeverityLow, SeverityMedium, SeverityHigh, SeverityCritical}
+	for i := 1; i < len(order); i++ {
+		if !(order[i-1] < order[i]) {
+			t.Fatalf("%v is not below %v", order[i-1], order[i])
+		}
+	}
+	if SeverityHigh.String() != "high" || SeverityUnknown.String() != "unknown" || Severity(42).String() != "Severity(42)" {
+		t.Fatal("unexpected String output")
+	}
+	if (Finding{Severity: "Critical"}).SeverityLevel() != SeverityCritical || (Finding{Severity: "urgent"}).SeverityLevel() != SeverityUnknown {
+		t.Fatal("unexpected SeverityLevel")
+	}
+}
+
+func TestSeverityJSON(t *testing.T) {
+	var v struct {
+		Min Severity `json:"min"`
+	}
+	if err := json.Unmarshal([]byte(`{"min":"medium"}`), &v); err != nil || v.Min != SeverityMedium {
+		t.Fatalf("decoded %v, %v", v.Min, err)
+	}
+	if err := json.Unmarshal([]byte(`{"min":"severe"}`), &v); err == nil {
+		t.Fatal("unknown severity decoded")
+	}
+	out, err := json.Marshal(v)
+	if err != nil || string(out) != `{"min":"medium"}` {
+		t.Fatalf("encoded %s, %v", out, err)
+	}
+	if _, err := json.Marshal(struct{ S Severity }{}); err == nil {
+		t.Fatal("SeverityUnknown encoded")
+	}
+}
@@ -0,0 +1,92 @@
+package tavo
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// discardHandler drops every record. It is the default slog handler, so
+// logging costs nothing until WithSlog is called.
+type discardHandler struct{}
+
+func (discardHandler) Enabled(context.Context, slog.Level) bool  { return false }
+func (discardHandler) Handle(context.Context, slog.Record) error { return nil }
+func (h discardHandler) WithAttrs([]slog.Attr) slog.Handler      { return h }
+func (h discardHandler) WithGroup(string) slog.Handler           { return h }
+
+var discardLogger = slog.New(discardHandler{})
+
+func (c *Client) slogger() *slog.Logger {
+	if c.config.Slog != nil {
+		return c.config.Slog
+	}
+	return discardLogger
+}
+
+// slogAttempt records one HTTP attempt. Successful attempts are logged at
+// debug level with the request headers, credentials redacted; error
+// statuses at warn and network errors at error level.
+func (c *Client) slogAttempt(ctx context.Context, req *apiRequest, attempt int, resp *resty.Response, err error, d time.Duration) {
+	log := c.slogger()
+	attrs := []slog.Attr{
+		slog.String("method", req.method),
+		slog.String("path", req.path),
+		slog.Int("attempt", attempt),
+		slog.Int64("duration_ms", d.Milliseconds()),
+	}
+	if err != nil {
+		log.LogAttrs(ctx, slog.LevelError, "tavo: request failed", append(attrs, slog.String("error", err.Error()))...)
+		return
+	}
+	status := resp.StatusCode()
+	attrs = append(attrs, slog.Int("status", status))
+	level := slog.LevelDebug
+	if status >= 400 {
+		level = slog.LevelWarn
+	}
+	if !log.Enabled(ctx, level) {
+		return
+	}
+	if level == slog.LevelDebug && resp.Request != nil && resp.Request.RawRequest != nil {
+		attrs = append(attrs, headerAttr(resp.Request.RawRequest.Header))
+	}
+	log.LogAttrs(ctx, level, "tavo: request", attrs...)
+}
+
+// slogRetry records a retry about to be sent.
+func (c *Client) slogRetry(ctx context.Context, req *apiRequest, attempt int, wait time.Duration, resp *resty.Response, err error) {
+	c.slogger().LogAttrs(ctx, slog.LevelInfo, "tavo: retrying request",
+		slog.String("method", req.method),
+		slog.String("path", req.path),
+		slog.Int("attempt", attempt),
+		slog.Int64("wait_ms", wait.Milliseconds()),
+		slog.String("reason", retryReason(resp, err)),
+	)
+}
+
+// headerAttr groups h under "headers", one attribute per header in name
+// order, with credential headers masked.
+func headerAttr(h http.Header) slog.Attr {
+	h = h.Clone()
+	redactHeader(h)
+	names := make([]string, 0, len(h))
+	for name := range h {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	attrs := make([]any, 0, len(names))
+	for _, name := range names {
+		v := h[name]
+		if len(v) == 1 {
+			attrs = append(attrs, slog.String(name, v[0]))
+		} else {
+			attrs = append(attrs, slog.Any(name, v))
+		}
+	}
+	return slog.Group("headers", attrs...)
+}
@@ -0,0 +1,92 @@
+package tavo
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+func TestWithSlog(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(apiHandler(t, func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			writeJSON(w, http.StatusServiceUnavailable, map[string]interface{}{"message": "busy"})
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{"id": "s1"})
+	}))
+	t.Cleanup(srv.Close)
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	c := newTestClientFor(t, srv, func(cfg *Config) { cfg.WithSlog(logger) })
+
+	if _, err := c.Scans().GetScan(context.Background(), "s1"); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(buf.String(), "test-key") {
+		t.Fatalf("API key leaked into log:\n%s", buf.String())
+	}
+
+	var records []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var rec map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &rec); err != nil {
+			t.Fatalf("bad record %q: %v", line, err)
+		}
+		records = append(records, rec)
+	}
+	if len(records) != 3 {
+		t.Fatalf("got %d records, want attempt, retry, attempt:\n%s", len(records), buf.String())
+	}
+
+	first, retry, last := records[0], records[1], records[2]
+	if first["level"] != "WARN" || first["status"] != 503.0 || first["attempt"] != 0.0 ||
+		first["method"] != "GET" || first["path"] != "/scans/s1" {
+		t.Errorf("first attempt = %v", first)
+	}
+	if _, ok := first["duration_ms"]; !ok {
+		t.Errorf("first attempt lacks duration_ms: %v", first)
+	}
+	if retry["msg"] != "tavo: retrying request" || retry["attempt"] != 1.0 || retry["reason"] != "status 503" {
+		t.Errorf("retry = %v", retry)
+	}
+	if last["level"] != "DEBUG" || last["status"] != 200.0 || last["attempt"] != 1.0 {
+		t.Errorf("last attempt = %v", last)
+	}
+	headers, _ := last["headers"].(map[string]interface{})
+	if headers["X-Api-Key"] != redacted {
+		t.Errorf("headers = %v, want X-Api-Key redacted", headers)
+	}
+}
+
+func TestSlogNetworkError(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	srv.Close()
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+	c := newTestClientFor(t, srv, func(cfg *Config) { cfg.WithSlog(logger).WithMaxRetries(0) })
+
+	if _, err := c.Scans().GetScan(context.Background(), "s1"); err == nil {
+		t.Fatal("expected an error from a closed server")
+	}
+	if out := buf.String(); !strings.Contains(out, "level=ERROR") || !strings.Contains(out, "error=") {
+		t.Fatalf("log = %q", out)
+	}
+}
+
+func TestSlogDefaultsToNoop(t *testing.T) {
+	c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]interface{}{})
+	})
+	if c.slogger().Enabled(context.Background(), slog.LevelError) {
+		t.Fatal("default logger is enabled")
+	}
+}
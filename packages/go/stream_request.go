@@ -0,0 +1,65 @@
+package tavo
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// doStreamed sends r with its body streamed from body. resty reads any
+// io.Reader body fully into memory before sending it, so that it can be
+// replayed on redirects; for uploads and reader bodies that defeats the
+// point. doStreamed sends the same URL, query, headers and credentials
+// through the client's transport chain with net/http directly. The body is
+// read into the returned response unless raw is set, in which case the
+// caller must close RawBody().
+func (c *Client) doStreamed(ctx context.Context, method, path string, r *resty.Request, body io.Reader, raw bool) (*resty.Response, error) {
+	u, err := url.Parse(c.http.BaseURL + path)
+	if err != nil {
+		return nil, err
+	}
+	q := u.Query()
+	for k, vs := range r.QueryParam {
+		for _, v := range vs {
+			q.Add(k, v)
+		}
+	}
+	u.RawQuery = q.Encode()
+
+	hreq, err := http.NewRequestWithContext(ctx, method, u.String(), body)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.setAPIHeaders(hreq.Header); err != nil {
+		return nil, err
+	}
+	for k, v := range r.Header {
+		hreq.Header[k] = v
+	}
+	if r.Token != "" {
+		hreq.Header.Set("Authorization", "Bearer "+r.Token)
+	}
+	r.RawRequest = hreq
+
+	hresp, err := c.http.GetClient().Do(hreq)
+	if err != nil {
+		return nil, err
+	}
+	if err := decodeContentEncoding(hresp); err != nil {
+		return nil, err
+	}
+	resp := &resty.Response{Request: r, RawResponse: hresp}
+	if raw {
+		return resp, nil
+	}
+	defer hresp.Body.Close()
+	data, err := io.ReadAll(hresp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("reading response: %w", err)
+	}
+	return resp.SetBody(data), nil
+}
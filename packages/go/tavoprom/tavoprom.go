@@ -0,0 +1,68 @@
+// Package tavoprom exports Tavo SDK request metrics to Prometheus. It is a
+// separate module so that the SDK itself does not depend on the Prometheus
+// client:
+//
+//	go get github.com/TavoAI/tavo-go-sdk/tavoprom
+//
+//	m, err := tavoprom.New(prometheus.DefaultRegisterer)
+//	if err != nil {
+//		...
+//	}
+//	client, err := tavo.NewClient(tavo.NewConfig().WithMetrics(m))
+package tavoprom
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// PrometheusMetrics implements tavo.Metrics with a request counter and a
+// latency histogram. Paths are not used as labels because they contain
+// resource IDs; requests are labelled by method and status class ("2xx",
+// "4xx", ... or "error" for network failures).
+type PrometheusMetrics struct {
+	requests *prometheus.CounterVec
+	latency  *prometheus.HistogramVec
+}
+
+// New creates the collectors and registers them with reg. Several clients
+// can share the returned value.
+func New(reg prometheus.Registerer) (*PrometheusMetrics, error) {
+	m := &PrometheusMetrics{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "tavo",
+			Subsystem: "client",
+			Name:      "requests_total",
+			Help:      "HTTP requests sent to the Tavo API, by method and status class.",
+		}, []string{"method", "status"}),
+		latency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: "tavo",
+			Subsystem: "client",
+			Name:      "request_duration_seconds",
+			Help:      "Latency of HTTP requests sent to the Tavo API.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "status"}),
+	}
+	for _, c := range []prometheus.Collector{m.requests, m.latency} {
+		if err := reg.Register(c); err != nil {
+			return nil, err
+		}
+	}
+	return m, nil
+}
+
+// ObserveRequest implements tavo.Metrics.
+func (m *PrometheusMetrics) ObserveRequest(method, path string, status int, duration time.Duration) {
+	class := statusClass(status)
+	m.requests.WithLabelValues(method, class).Inc()
+	m.latency.WithLabelValues(method, class).Observe(duration.Seconds())
+}
+
+func statusClass(status int) string {
+	if status <= 0 {
+		return "error"
+	}
+	return strconv.Itoa(status/100) + "xx"
+}
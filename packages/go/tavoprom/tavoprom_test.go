@@ -0,0 +1,45 @@
+package tavoprom
+
+import (
+	"testing"
+	"time"
+
+	tavo "github.com/TavoAI/tavo-go-sdk"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+var _ tavo.Metrics = (*PrometheusMetrics)(nil)
+
+func TestPrometheusMetrics(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	m, err := New(reg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m.ObserveRequest("GET", "/scans/1", 200, 10*time.Millisecond)
+	m.ObserveRequest("GET", "/scans/2", 204, 10*time.Millisecond)
+	m.ObserveRequest("POST", "/scans", 503, time.Second)
+	m.ObserveRequest("GET", "/scans/3", 0, time.Second)
+
+	for _, tc := range []struct {
+		method, class string
+		want          float64
+	}{
+		{"GET", "2xx", 2},
+		{"POST", "5xx", 1},
+		{"GET", "error", 1},
+	} {
+		if got := testutil.ToFloat64(m.requests.WithLabelValues(tc.method, tc.class)); got != tc.want {
+			t.Errorf("requests{%s,%s} = %v, want %v", tc.method, tc.class, got, tc.want)
+		}
+	}
+	if n := testutil.CollectAndCount(m.latency); n != 3 {
+		t.Errorf("latency series = %d, want 3", n)
+	}
+
+	if _, err := New(reg); err == nil {
+		t.Error("registering twice should fail")
+	}
+}
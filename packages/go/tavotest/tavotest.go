@@ -0,0 +1,174 @@
+// Package tavotest provides an in-process fake of the Tavo AI API for
+// testing code that uses the SDK without network access:
+//
+//	mock := tavotest.NewMockServer()
+//	defer mock.Close()
+//	mock.On("GET", "/scans/123").Return(200, `{"id":"123","status":"completed"}`)
+//
+//	scan, err := mock.Client().Scans().GetScan(ctx, "123")
+package tavotest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"sync"
+	"time"
+
+	tavo "github.com/TavoAI/tavo-go-sdk"
+)
+
+// versionPrefix matches the /api/{version} prefix of client requests.
+var versionPrefix = regexp.MustCompile(`^/api/v[0-9]+(/|$)`)
+
+// MockServer is an httptest.Server that answers with canned responses
+// registered through On. Requests without a registered response get a 404
+// API error. It is safe for concurrent use.
+type MockServer struct {
+	*httptest.Server
+
+	mu        sync.Mutex
+	responses map[string]*Response
+	requests  []Request
+}
+
+// Response is a canned response for one method and path.
+type Response struct {
+	mock   *MockServer
+	status int
+	body   []byte
+	header http.Header
+}
+
+// Request is a request received by a MockServer.
+type Request struct {
+	Method string
+	// Path is the operation path, without the /api/{version} prefix.
+	Path   string
+	Query  map[string][]string
+	Header http.Header
+	Body   []byte
+}
+
+// NewMockServer starts a MockServer. Call Close when done.
+func NewMockServer() *MockServer {
+	m := &MockServer{responses: make(map[string]*Response)}
+	m.Server = httptest.NewServer(http.HandlerFunc(m.serve))
+	return m
+}
+
+// On registers the response for method and path, replacing any earlier
+// registration. The path is an operation path such as "/scans/123"; the
+// /api/{version} prefix the client adds is ignored when matching. The
+// query string is not part of the match.
+func (m *MockServer) On(method, path string) *Response {
+	r := &Response{mock: m, status: http.StatusOK, header: http.Header{}}
+	m.mu.Lock()
+	m.responses[method+" "+path] = r
+	m.mu.Unlock()
+	return r
+}
+
+// Return sets the status and body of the response. A string or []byte body
+// is sent as is; any other value is encoded as JSON.
+func (r *Response) Return(status int, body interface{}) *Response {
+	var data []byte
+	switch b := body.(type) {
+	case nil:
+	case string:
+		data = []byte(b)
+	case []byte:
+		data = b
+	default:
+		var err error
+		if data, err = json.Marshal(b); err != nil {
+			panic(fmt.Sprintf("tavotest: encoding response body: %v", err))
+		}
+	}
+	r.mock.mu.Lock()
+	r.status, r.body = status, data
+	r.mock.mu.Unlock()
+	return r
+}
+
+// WithHeader adds a response header.
+func (r *Response) WithHeader(key, value string) *Response {
+	r.mock.mu.Lock()
+	r.header.Add(key, value)
+	r.mock.mu.Unlock()
+	return r
+}
+
+// Requests returns the requests received so far, in arrival order.
+func (m *MockServer) Requests() []Request {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]Request(nil), m.requests...)
+}
+
+// Client returns a client configured to talk to the mock server with a
+// test API key. Retries are disabled so canned errors surface directly.
+func (m *MockServer) Client() *tavo.Client {
+	cfg := &tavo.Config{
+		APIKey:     "tavotest-key",
+		BaseURL:    m.URL,
+		APIVersion: tavo.DefaultAPIVersion,
+		Timeout:    10 * time.Second,
+		RetryWait:  time.Millisecond,
+	}
+	c, err := tavo.NewClient(cfg)
+	if err != nil {
+		panic(fmt.Sprintf("tavotest: building client: %v", err))
+	}
+	return c
+}
+
+func (m *MockServer) serve(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	path := versionPrefix.ReplaceAllString(r.URL.Path, "$1")
+	if path == "" {
+		path = "/"
+	}
+
+	m.mu.Lock()
+	m.requests = append(m.requests, Request{
+		Method: r.Method,
+		Path:   path,
+		Query:  r.URL.Query(),
+		Header: r.Header.Clone(),
+		Body:   body,
+	})
+	resp, ok := m.responses[r.Method+" "+path]
+	var (
+		status int
+		data   []byte
+		header http.Header
+	)
+	if ok {
+		status, data, header = resp.status, resp.body, resp.header.Clone()
+	}
+	m.mu.Unlock()
+
+	if !ok {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"error": map[string]string{
+				"code":    "not_found",
+				"message": fmt.Sprintf("tavotest: no response registered for %s %s", r.Method, path),
+			},
+		})
+		return
+	}
+	for k, vs := range header {
+		w.Header()[k] = vs
+	}
+	if w.Header().Get("Content-Type") == "" && len(data) > 0 {
+		w.Header().Set("Content-Type", "application/json")
+	}
+	w.WriteHeader(status)
+	w.Write(data)
+}
@@ -0,0 +1,54 @@
+package tavotest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	tavo "github.com/TavoAI/tavo-go-sdk"
+)
+
+func TestMockServerCannedResponses(t *testing.T) {
+	mock := NewMockServer()
+	defer mock.Close()
+	mock.On("GET", "/scans/123").Return(200, `{"id":"123","status":"completed"}`)
+	mock.On("POST", "/scans").Return(201, map[string]interface{}{"id": "456"})
+
+	ctx := context.Background()
+	client := mock.Client()
+
+	scan, err := client.Scans().GetScan(ctx, "123")
+	if err != nil || scan["status"] != "completed" {
+		t.Fatalf("scan = %v, err = %v", scan, err)
+	}
+	created, err := client.Scans().CreateScan(ctx, map[string]interface{}{"name": "nightly"})
+	if err != nil || created["id"] != "456" {
+		t.Fatalf("created = %v, err = %v", created, err)
+	}
+
+	reqs := mock.Requests()
+	if len(reqs) != 2 || reqs[1].Method != "POST" || string(reqs[1].Body) != `{"name":"nightly"}` {
+		t.Fatalf("requests = %+v", reqs)
+	}
+	if reqs[0].Header.Get("X-API-Key") != "tavotest-key" {
+		t.Fatalf("headers = %v", reqs[0].Header)
+	}
+}
+
+func TestMockServerErrors(t *testing.T) {
+	mock := NewMockServer()
+	defer mock.Close()
+	mock.On("GET", "/scans/bad").Return(http.StatusForbidden, `{"code":"forbidden","message":"no access"}`)
+
+	_, err := mock.Client().Scans().GetScan(context.Background(), "bad")
+	var tErr *tavo.TavoError
+	if !errors.As(err, &tErr) || tErr.StatusCode != 403 || tErr.Code != "forbidden" {
+		t.Fatalf("err = %v", err)
+	}
+
+	_, err = mock.Client().Scans().GetScan(context.Background(), "unregistered")
+	if !errors.As(err, &tErr) || tErr.StatusCode != 404 || tErr.Code != "not_found" {
+		t.Fatalf("err = %v", err)
+	}
+}
@@ -0,0 +1,16 @@
+package tavo
+
+// TokenSource supplies bearer tokens. Token is called before every request,
+// possibly from several goroutines at once, so implementations backed by a
+// secrets manager should cache and refresh the token themselves.
+type TokenSource interface {
+	Token() (string, error)
+}
+
+// StaticTokenSource is a TokenSource that always returns the same token.
+type StaticTokenSource string
+
+// Token returns the static token.
+func (s StaticTokenSource) Token() (string, error) {
+	return string(s), nil
+}
@@ -0,0 +1,83 @@
+package tavo
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+type rotatingTokenSource struct {
+	mu sync.Mutex
+	n  int
+}
+
+func (s *rotatingTokenSource) Token() (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.n++
+	return fmt.Sprintf("tok-%d", s.n), nil
+}
+
+func TestTokenSourceCalledPerRequest(t *testing.T) {
+	var seen []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-API-Key") != "" {
+			t.Error("static API key sent alongside token source")
+		}
+		seen = append(seen, r.Header.Get("Authorization"))
+		writeJSON(w, http.StatusOK, map[string]interface{}{})
+	}))
+	defer srv.Close()
+	c := newTestClientFor(t, srv, func(cfg *Config) {
+		cfg.WithTokenSource(&rotatingTokenSource{})
+	})
+
+	for i := 0; i < 2; i++ {
+		if _, err := c.Jobs().GetJob(context.Background(), "j"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(seen) != 2 || seen[0] != "Bearer tok-1" || seen[1] != "Bearer tok-2" {
+		t.Fatalf("Authorization headers = %v", seen)
+	}
+}
+
+type failingTokenSource struct{}
+
+func (failingTokenSource) Token() (string, error) { return "", errors.New("vault sealed") }
+
+func TestTokenSourceError(t *testing.T) {
+	c, err := NewClient(&Config{BaseURL: "http://127.0.0.1:0", TokenSource: failingTokenSource{}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = c.Jobs().GetJob(context.Background(), "j")
+	if err == nil || err.Error() != "tavo: fetching token: vault sealed" {
+		t.Fatalf("err = %v", err)
+	}
+
+	if tok, _ := StaticTokenSource("abc").Token(); tok != "abc" {
+		t.Fatalf("StaticTokenSource = %q", tok)
+	}
+}
+
+func TestTokenSourceAppliesToDownloads(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "Bearer dl" {
+			t.Errorf("Authorization = %q", got)
+		}
+		w.Write([]byte("report"))
+	}))
+	defer srv.Close()
+	c := newTestClientFor(t, srv, func(cfg *Config) { cfg.WithTokenSource(StaticTokenSource("dl")) })
+
+	var buf bytes.Buffer
+	if err := c.Reports().DownloadReportTo(context.Background(), "r1", &buf); err != nil || buf.String() != "report" {
+		t.Fatalf("body = %q, err = %v", buf.String(), err)
+	}
+}
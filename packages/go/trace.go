@@ -0,0 +1,50 @@
+package tavo
+
+import (
+	"context"
+	"net/http"
+	"strings"
+)
+
+// contextKey is the type of context keys defined by this package.
+type contextKey struct{ name string }
+
+func (k *contextKey) String() string { return "tavo context key " + k.name }
+
+// TraceIDKey is the context key of the trace ID sent as X-Trace-Id with
+// every API request made with the context. Set it with WithTraceID, or
+// with context.WithValue and a string value.
+var TraceIDKey = &contextKey{"trace-id"}
+
+// traceIDHeader carries the caller's trace ID to the API.
+const traceIDHeader = "X-Trace-Id"
+
+// WithTraceID returns a copy of ctx whose API requests carry id in the
+// X-Trace-Id header.
+func WithTraceID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, TraceIDKey, id)
+}
+
+// TraceIDFromContext returns the trace ID attached to ctx, or "".
+func TraceIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(TraceIDKey).(string)
+	return id
+}
+
+// traceTransport sets X-Trace-Id on requests to the API host from the
+// request's context. A header set explicitly, for example with WithHeader,
+// is left alone.
+type traceTransport struct {
+	next http.RoundTripper
+	host string
+}
+
+func (t *traceTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	id := TraceIDFromContext(req.Context())
+	if id == "" || req.Header.Get(traceIDHeader) != "" || !strings.EqualFold(req.URL.Host, t.host) {
+		return t.next.RoundTrip(req)
+	}
+	req = req.Clone(req.Context())
+	req.Header.Set(traceIDHeader, id)
+	return t.next.RoundTrip(req)
+}
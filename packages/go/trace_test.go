@@ -0,0 +1,70 @@
+package tavo
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestTraceIDHeader(t *testing.T) {
+	var got []string
+	c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		got = append(got, r.Header.Get("X-Trace-Id"))
+		writeJSON(w, http.StatusOK, map[string]interface{}{})
+	})
+
+	ctx := WithTraceID(context.Background(), "trace-1")
+	if TraceIDFromContext(ctx) != "trace-1" {
+		t.Fatalf("TraceIDFromContext = %q", TraceIDFromContext(ctx))
+	}
+	if _, err := c.Scans().GetScan(ctx, "s1"); err != nil {
+		t.Fatal(err)
+	}
+	// A value set directly under TraceIDKey works too.
+	if _, err := c.Scans().GetScan(context.WithValue(context.Background(), TraceIDKey, "trace-2"), "s1"); err != nil {
+		t.Fatal(err)
+	}
+	// An explicit header wins over the context.
+	if _, err := c.Do(ctx, http.MethodGet, "/scans", nil, nil, WithHeader("X-Trace-Id", "explicit")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Scans().GetScan(context.Background(), "s1"); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"trace-1", "trace-2", "explicit", ""}
+	if len(got) != len(want) {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("request %d X-Trace-Id = %q, want %q", i, got[i], want[i])
+		}
+	}
+}
+
+func TestTraceIDOnStreamsNotForeignHosts(t *testing.T) {
+	var foreignTrace string
+	foreign := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		foreignTrace = r.Header.Get("X-Trace-Id")
+	}))
+	t.Cleanup(foreign.Close)
+	var apiTrace string
+	c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		apiTrace = r.Header.Get("X-Trace-Id")
+		w.Write([]byte("pdf"))
+	})
+	ctx := WithTraceID(context.Background(), "trace-3")
+
+	if err := c.download(ctx, "/reports/r1/download", "application/pdf", &bytes.Buffer{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.FetchURL(ctx, foreign.URL, &bytes.Buffer{}); err != nil {
+		t.Fatal(err)
+	}
+	if apiTrace != "trace-3" || foreignTrace != "" {
+		t.Fatalf("api trace = %q, foreign trace = %q", apiTrace, foreignTrace)
+	}
+}
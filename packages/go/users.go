@@ -0,0 +1,253 @@
+package tavo
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// UserOperations groups the /users and /api-keys endpoints.
+type UserOperations struct {
+	client *Client
+
+	cacheMu     sync.Mutex
+	cachedMe    map[string]interface{}
+	cachedUntil time.Time
+}
+
+// GetCurrentUser fetches the authenticated user. With Config.WithUserCache
+// the result is reused until the TTL expires.
+func (u *UserOperations) GetCurrentUser(ctx context.Context) (map[string]interface{}, error) {
+	ttl := u.client.config.UserCacheTTL
+	if ttl > 0 {
+		u.cacheMu.Lock()
+		me, until := u.cachedMe, u.cachedUntil
+		u.cacheMu.Unlock()
+		if me != nil && time.Now().Before(until) {
+			return copyParams(me), nil
+		}
+	}
+	me, err := u.client.makeRequest(ctx, http.MethodGet, "/users/me", nil, nil)
+	if err != nil || ttl <= 0 {
+		return me, err
+	}
+	u.cacheMu.Lock()
+	u.cachedMe, u.cachedUntil = copyParams(me), time.Now().Add(ttl)
+	u.cacheMu.Unlock()
+	return me, nil
+}
+
+// UpdateProfile updates the authenticated user's profile and drops the
+// cached current user.
+func (u *UserOperations) UpdateProfile(ctx context.Context, data map[string]interface{}) (map[string]interface{}, error) {
+	defer u.invalidateCache()
+	return u.client.makeRequest(ctx, http.MethodPut, "/users/me", data, nil)
+}
+
+func (u *UserOperations) invalidateCache() {
+	u.cacheMu.Lock()
+	u.cachedMe = nil
+	u.cacheMu.Unlock()
+}
+
+// InvalidateUserCache drops the current user cached by GetCurrentUser, for
+// example after the profile was changed outside this client.
+func (c *Client) InvalidateUserCache() {
+	c.users.invalidateCache()
+}
+
+// GetUser fetches a user by ID.
+func (u *UserOperations) GetUser(ctx context.Context, userID string) (map[string]interface{}, error) {
+	return u.client.makeRequest(ctx, http.MethodGet, "/users/"+userID, nil, nil)
+}
+
+// User is a Tavo user account.
+type User struct {
+	ID        string    `json:"id"`
+	Email     string    `json:"email"`
+	Name      string    `json:"name"`
+	Roles     []string  `json:"roles"`
+	OrgID     string    `json:"organization_id"`
+	CreatedAt time.Time `json:"created_at"`
+
+	// Extra holds response fields without a dedicated field above.
+	Extra map[string]interface{} `json:"-"`
+}
+
+// HasRole reports whether the user has role.
+func (u *User) HasRole(role string) bool {
+	for _, r := range u.Roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+// GetCurrentUserTyped fetches the authenticated user as a User.
+func (u *UserOperations) GetCurrentUserTyped(ctx context.Context) (*User, error) {
+	resp, err := u.GetCurrentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return decodeUser(resp)
+}
+
+// GetUserTyped fetches a user by ID as a User.
+func (u *UserOperations) GetUserTyped(ctx context.Context, userID string) (*User, error) {
+	resp, err := u.GetUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return decodeUser(resp)
+}
+
+func decodeUser(resp map[string]interface{}) (*User, error) {
+	var user User
+	if err := decodeMap(resp, &user); err != nil {
+		return nil, err
+	}
+	user.Extra = extraFields(resp, &user)
+	return &user, nil
+}
+
+// ListUsers lists users visible to the caller.
+func (u *UserOperations) ListUsers(ctx context.Context, params map[string]interface{}) (map[string]interface{}, error) {
+	return u.client.makeRequest(ctx, http.MethodGet, "/users", nil, params)
+}
+
+// UserFilter narrows the users returned by ListUsersTyped.
+type UserFilter struct {
+	Email string
+	Role  string
+	OrgID string
+	// Search matches names and emails, sent as the q param.
+	Search string
+	// Active keeps only active (true) or inactive (false) users; nil
+	// returns both.
+	Active *bool
+	Limit  int
+	Offset int
+}
+
+func (f UserFilter) params() map[string]interface{} {
+	params := map[string]interface{}{}
+	if f.Email != "" {
+		params["email"] = f.Email
+	}
+	if f.Role != "" {
+		params["role"] = f.Role
+	}
+	if f.OrgID != "" {
+		params["organization_id"] = f.OrgID
+	}
+	if f.Search != "" {
+		params["q"] = f.Search
+	}
+	if f.Active != nil {
+		params["active"] = *f.Active
+	}
+	if f.Limit > 0 {
+		params["limit"] = f.Limit
+	}
+	if f.Offset > 0 {
+		params["offset"] = f.Offset
+	}
+	return params
+}
+
+// ListUsersTyped lists the users matching filter as Users.
+func (u *UserOperations) ListUsersTyped(ctx context.Context, filter UserFilter) ([]User, error) {
+	resp, err := u.ListUsers(ctx, filter.params())
+	if err != nil {
+		return nil, err
+	}
+	items, _ := pageItems(resp, filter.Offset)
+	users := make([]User, 0, len(items))
+	for _, item := range items {
+		user, err := decodeUser(item)
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, *user)
+	}
+	return users, nil
+}
+
+// ListAPIKeys lists the caller's API keys.
+func (u *UserOperations) ListAPIKeys(ctx context.Context) (map[string]interface{}, error) {
+	return u.client.makeRequest(ctx, http.MethodGet, "/api-keys", nil, nil)
+}
+
+// GetAPIKey fetches an API key's metadata.
+func (u *UserOperations) GetAPIKey(ctx context.Context, keyID string) (map[string]interface{}, error) {
+	return u.client.makeRequest(ctx, http.MethodGet, "/api-keys/"+keyID, nil, nil)
+}
+
+// CreateAPIKey creates an API key. The response carries the key secret,
+// which the API never returns again.
+func (u *UserOperations) CreateAPIKey(ctx context.Context, name string, opts map[string]interface{}) (map[string]interface{}, error) {
+	data := copyParams(opts)
+	data["name"] = name
+	return u.client.makeRequest(ctx, http.MethodPost, "/api-keys", data, nil)
+}
+
+// RotateAPIKey replaces an API key's secret.
+func (u *UserOperations) RotateAPIKey(ctx context.Context, keyID string) (map[string]interface{}, error) {
+	return u.client.makeRequest(ctx, http.MethodPost, "/api-keys/"+keyID+"/rotate", nil, nil)
+}
+
+// DeleteAPIKey revokes an API key.
+func (u *UserOperations) DeleteAPIKey(ctx context.Context, keyID string) error {
+	_, err := u.client.makeRequest(ctx, http.MethodDelete, "/api-keys/"+keyID, nil, nil)
+	return err
+}
+
+// APIKey is an API key's metadata.
+//
+// Secret holds the usable key and is only populated in the responses of
+// CreateAPIKeyTyped and RotateAPIKeyTyped. It is always empty on
+// ListAPIKeys and GetAPIKey because the API never returns a secret twice,
+// so callers must capture it at creation time.
+type APIKey struct {
+	ID         string     `json:"id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	CreatedAt  time.Time  `json:"created_at"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	Secret     string     `json:"secret,omitempty"`
+}
+
+// CreateAPIKeyTyped creates an API key and returns it with its one-time
+// Secret.
+func (u *UserOperations) CreateAPIKeyTyped(ctx context.Context, name string, opts map[string]interface{}) (*APIKey, error) {
+	resp, err := u.CreateAPIKey(ctx, name, opts)
+	if err != nil {
+		return nil, err
+	}
+	return decodeAPIKey(resp)
+}
+
+// RotateAPIKeyTyped rotates an API key and returns it with its new one-time
+// Secret.
+func (u *UserOperations) RotateAPIKeyTyped(ctx context.Context, keyID string) (*APIKey, error) {
+	resp, err := u.RotateAPIKey(ctx, keyID)
+	if err != nil {
+		return nil, err
+	}
+	return decodeAPIKey(resp)
+}
+
+// decodeAPIKey decodes an API key response. The secret is returned as
+// "secret" or, on older API versions, "key".
+func decodeAPIKey(resp map[string]interface{}) (*APIKey, error) {
+	var key APIKey
+	if err := decodeMap(resp, &key); err != nil {
+		return nil, err
+	}
+	if key.Secret == "" {
+		key.Secret, _ = resp["key"].(string)
+	}
+	return &key, nil
+}
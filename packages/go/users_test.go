@@ -0,0 +1,198 @@
+package tavo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCreateAPIKeyTyped(t *testing.T) {
+	c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		if body["name"] != "ci" || body["expires_in_days"] != float64(30) {
+			t.Errorf("body = %v", body)
+		}
+		writeJSON(w, http.StatusCreated, map[string]interface{}{
+			"id":           "key-1",
+			"name":         "ci",
+			"prefix":       "tavo_ab",
+			"created_at":   "2025-01-02T03:04:05Z",
+			"last_used_at": nil,
+			"key":          "tavo_ab.secret",
+		})
+	})
+
+	key, err := c.Users().CreateAPIKeyTyped(context.Background(), "ci", map[string]interface{}{"expires_in_days": 30})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if key.ID != "key-1" || key.Prefix != "tavo_ab" || key.Secret != "tavo_ab.secret" {
+		t.Fatalf("key = %+v", key)
+	}
+	if key.CreatedAt.Year() != 2025 || key.LastUsedAt != nil {
+		t.Fatalf("key = %+v", key)
+	}
+}
+
+func TestGetUserTyped(t *testing.T) {
+	c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/users/u1" {
+			t.Errorf("path = %s", r.URL.Path)
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"id":              "u1",
+			"email":           "ada@example.com",
+			"name":            "Ada",
+			"roles":           []string{"member", "admin"},
+			"organization_id": "o1",
+			"created_at":      "2025-03-01T12:00:00Z",
+			"mfa_enabled":     true,
+		})
+	})
+
+	user, err := c.Users().GetUserTyped(context.Background(), "u1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if user.Email != "ada@example.com" || user.OrgID != "o1" || user.CreatedAt.Year() != 2025 {
+		t.Fatalf("user = %+v", user)
+	}
+	if !user.HasRole("admin") || user.HasRole("owner") {
+		t.Fatalf("roles = %v", user.Roles)
+	}
+	if len(user.Extra) != 1 || user.Extra["mfa_enabled"] != true {
+		t.Fatalf("Extra = %v", user.Extra)
+	}
+}
+
+func TestGetCurrentUserTyped(t *testing.T) {
+	c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/users/me" {
+			t.Errorf("path = %s", r.URL.Path)
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{"id": "me", "roles": []string{"owner"}})
+	})
+	user, err := c.Users().GetCurrentUserTyped(context.Background())
+	if err != nil || user.ID != "me" || !user.HasRole("owner") || user.Extra != nil {
+		t.Fatalf("user = %+v, err = %v", user, err)
+	}
+}
+
+func TestUserCache(t *testing.T) {
+	var gets int32
+	c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			n := atomic.AddInt32(&gets, 1)
+			writeJSON(w, http.StatusOK, map[string]interface{}{"id": "u1", "name": fmt.Sprint("v", n)})
+		case http.MethodPut:
+			writeJSON(w, http.StatusOK, map[string]interface{}{"id": "u1"})
+		}
+	})
+	c.config.UserCacheTTL = 50 * time.Millisecond
+	ctx := context.Background()
+	name := func() string {
+		t.Helper()
+		me, err := c.Users().GetCurrentUser(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return me["name"].(string)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, _ = c.Users().GetCurrentUser(ctx)
+		}()
+	}
+	wg.Wait()
+	first := name()
+	me, _ := c.Users().GetCurrentUser(ctx)
+	me["name"] = "mutated"
+	if got := name(); got != first {
+		t.Fatalf("cached name = %q, want %q", got, first)
+	}
+
+	time.Sleep(60 * time.Millisecond)
+	afterTTL := name()
+	if afterTTL == first {
+		t.Fatal("cache not refreshed after TTL")
+	}
+
+	if _, err := c.Users().UpdateProfile(ctx, map[string]interface{}{"name": "x"}); err != nil {
+		t.Fatal(err)
+	}
+	afterUpdate := name()
+	if afterUpdate == afterTTL {
+		t.Fatal("UpdateProfile did not invalidate the cache")
+	}
+
+	c.InvalidateUserCache()
+	if name() == afterUpdate {
+		t.Fatal("InvalidateUserCache did not invalidate the cache")
+	}
+}
+
+func TestUserCacheDisabledByDefault(t *testing.T) {
+	gets := 0
+	c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		gets++
+		writeJSON(w, http.StatusOK, map[string]interface{}{"id": "u1"})
+	})
+	for i := 0; i < 2; i++ {
+		if _, err := c.Users().GetCurrentUser(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if gets != 2 {
+		t.Fatalf("gets = %d, want 2", gets)
+	}
+}
+
+func TestListUsersTyped(t *testing.T) {
+	var queries []string
+	c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/users" {
+			t.Errorf("path = %s", r.URL.Path)
+		}
+		queries = append(queries, r.URL.RawQuery)
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"items": []interface{}{
+				map[string]interface{}{"id": "u1", "email": "ada@example.com", "name": "Ada", "roles": []string{"admin"}, "active": true},
+			},
+			"total": 1,
+		})
+	})
+	ctx := context.Background()
+
+	inactive := false
+	users, err := c.Users().ListUsersTyped(ctx, UserFilter{Search: "ada", Role: "admin", OrgID: "o1", Active: &inactive, Limit: 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(users) != 1 || users[0].Email != "ada@example.com" || !users[0].HasRole("admin") || users[0].Extra["active"] != true {
+		t.Fatalf("users = %+v", users)
+	}
+	if _, err := c.Users().ListUsersTyped(ctx, UserFilter{Email: "ada@example.com"}); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{
+		"active=false&limit=5&organization_id=o1&q=ada&role=admin",
+		"email=ada%40example.com",
+	}
+	for i := range want {
+		if queries[i] != want[i] {
+			t.Errorf("query %d = %q, want %q", i, queries[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,21 @@
+package tavo
+
+import "runtime"
+
+// Version is the SDK release.
+const Version = "0.4.0"
+
+// sdkUserAgent identifies the SDK and Go runtime, e.g.
+// "tavo-go-sdk/0.4.0 go1.21.5".
+func sdkUserAgent() string {
+	return "tavo-go-sdk/" + Version + " " + runtime.Version()
+}
+
+// userAgent is the User-Agent sent by a client built from config: the
+// application's own product token, if any, followed by the SDK's.
+func userAgent(config *Config) string {
+	if config.UserAgent == "" {
+		return sdkUserAgent()
+	}
+	return config.UserAgent + " " + sdkUserAgent()
+}
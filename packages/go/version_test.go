@@ -0,0 +1,31 @@
+package tavo
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"testing"
+)
+
+func TestUserAgent(t *testing.T) {
+	sdk := "tavo-go-sdk/" + Version + " " + runtime.Version()
+	for _, tc := range []struct{ ua, want string }{
+		{"", sdk},
+		{"acme-ci/2.3", "acme-ci/2.3 " + sdk},
+	} {
+		var got string
+		srv := httptest.NewServer(apiHandler(t, func(w http.ResponseWriter, r *http.Request) {
+			got = r.Header.Get("User-Agent")
+			writeJSON(w, http.StatusOK, map[string]interface{}{})
+		}))
+		c := newTestClientFor(t, srv, func(cfg *Config) { cfg.WithUserAgent(tc.ua) })
+		if _, err := c.Scans().GetScan(context.Background(), "s1"); err != nil {
+			t.Fatal(err)
+		}
+		srv.Close()
+		if got != tc.want {
+			t.Errorf("User-Agent = %q, want %q", got, tc.want)
+		}
+	}
+}
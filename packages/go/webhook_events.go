@@ -0,0 +1,112 @@
+package tavo
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Webhook event types sent in the "type" field of a delivery.
+const (
+	EventScanStarted       = "scan.started"
+	EventScanCompleted     = "scan.completed"
+	EventScanFailed        = "scan.failed"
+	EventJobFinished       = "job.finished"
+	EventJobFailed         = "job.failed"
+	EventReportReady       = "report.ready"
+	EventAnalysisCompleted = "analysis.completed"
+)
+
+// WebhookEvents lists every event type a webhook can subscribe to.
+var WebhookEvents = []string{
+	EventScanStarted,
+	EventScanCompleted,
+	EventScanFailed,
+	EventJobFinished,
+	EventJobFailed,
+	EventReportReady,
+	EventAnalysisCompleted,
+}
+
+// WebhookEvent is the envelope of a webhook delivery.
+type WebhookEvent struct {
+	Type      string          `json:"type"`
+	ID        string          `json:"id"`
+	Timestamp time.Time       `json:"timestamp"`
+	Data      json.RawMessage `json:"data"`
+}
+
+// DecodeData unmarshals the event payload into v.
+func (e *WebhookEvent) DecodeData(v interface{}) error {
+	if len(e.Data) == 0 {
+		return errors.New("tavo: webhook event has no data")
+	}
+	if err := json.Unmarshal(e.Data, v); err != nil {
+		return fmt.Errorf("tavo: decoding %s event data: %w", e.Type, err)
+	}
+	return nil
+}
+
+// WebhookSignatureHeader is the delivery header carrying the body's
+// signature, checked by VerifySignature.
+const WebhookSignatureHeader = "X-Tavo-Signature"
+
+// ErrInvalidSignature is returned by VerifySignature when a delivery was
+// not signed with any of the given secrets.
+var ErrInvalidSignature = errors.New("tavo: invalid webhook signature")
+
+// VerifySignature checks a delivery's WebhookSignatureHeader value against
+// its raw body. The header holds "sha256=" followed by the hex HMAC-SHA256
+// of the body keyed with the webhook secret (WebhookConfig.Secret, or the
+// Secret returned by RotateSecret). During a rotation grace period the
+// delivery is signed with both secrets and the header lists both
+// signatures, separated by commas. The delivery is accepted if any
+// signature matches any of secrets, so a receiver can pass its old and new
+// secret while switching over.
+func (w *WebhookOperations) VerifySignature(body []byte, signature string, secrets ...string) error {
+	if len(secrets) == 0 {
+		return errors.New("tavo: no webhook secret to verify against")
+	}
+	for _, sig := range strings.Split(signature, ",") {
+		hexSig, ok := strings.CutPrefix(strings.TrimSpace(sig), "sha256=")
+		if !ok {
+			continue
+		}
+		got, err := hex.DecodeString(hexSig)
+		if err != nil {
+			continue
+		}
+		for _, secret := range secrets {
+			mac := hmac.New(sha256.New, []byte(secret))
+			mac.Write(body)
+			if hmac.Equal(got, mac.Sum(nil)) {
+				return nil
+			}
+		}
+	}
+	return ErrInvalidSignature
+}
+
+// ParseEvent decodes a webhook delivery body and checks that the envelope
+// carries a type, an ID and a timestamp. Check the delivery with
+// VerifySignature before trusting the result.
+func (w *WebhookOperations) ParseEvent(body []byte) (*WebhookEvent, error) {
+	var event WebhookEvent
+	if err := json.Unmarshal(body, &event); err != nil {
+		return nil, fmt.Errorf("tavo: decoding webhook event: %w", err)
+	}
+	switch {
+	case event.Type == "":
+		return nil, errors.New("tavo: webhook event is missing type")
+	case event.ID == "":
+		return nil, errors.New("tavo: webhook event is missing id")
+	case event.Timestamp.IsZero():
+		return nil, errors.New("tavo: webhook event is missing timestamp")
+	}
+	return &event, nil
+}
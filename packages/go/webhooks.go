@@ -0,0 +1,183 @@
+package tavo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// WebhookOperations groups the /webhooks endpoints.
+type WebhookOperations struct {
+	client *Client
+}
+
+// ListWebhooks lists configured webhooks.
+func (w *WebhookOperations) ListWebhooks(ctx context.Context, params map[string]interface{}) (map[string]interface{}, error) {
+	return w.client.makeRequest(ctx, http.MethodGet, "/webhooks", nil, params)
+}
+
+// GetWebhook fetches a webhook by ID.
+func (w *WebhookOperations) GetWebhook(ctx context.Context, webhookID string) (map[string]interface{}, error) {
+	return w.client.makeRequest(ctx, http.MethodGet, "/webhooks/"+webhookID, nil, nil)
+}
+
+// CreateWebhook registers a webhook.
+func (w *WebhookOperations) CreateWebhook(ctx context.Context, data map[string]interface{}) (map[string]interface{}, error) {
+	return w.client.makeRequest(ctx, http.MethodPost, "/webhooks", data, nil)
+}
+
+// WebhookConfig describes a webhook to create with CreateWebhookTyped.
+type WebhookConfig struct {
+	// URL receives the deliveries. It must use https.
+	URL string `json:"url"`
+	// Events are the event types to deliver, from WebhookEvents.
+	Events []string `json:"events"`
+	// Secret signs deliveries. When empty the server generates one and
+	// returns it in Webhook.Secret.
+	Secret string `json:"secret,omitempty"`
+	Active bool   `json:"active"`
+	// Headers are sent with every delivery.
+	Headers map[string]string `json:"headers,omitempty"`
+}
+
+// Validate reports whether the config can be sent: the URL must be an
+// absolute https URL and every event a known event type.
+func (c WebhookConfig) Validate() error {
+	u, err := url.Parse(c.URL)
+	if err != nil || u.Host == "" {
+		return fmt.Errorf("tavo: webhook URL %q is not an absolute URL", c.URL)
+	}
+	if u.Scheme != "https" {
+		return fmt.Errorf("tavo: webhook URL %q must use https", c.URL)
+	}
+	if len(c.Events) == 0 {
+		return errors.New("tavo: webhook must subscribe to at least one event")
+	}
+	for _, e := range c.Events {
+		if !knownEvent(e) {
+			return fmt.Errorf("tavo: unknown webhook event %q (want one of %v)", e, WebhookEvents)
+		}
+	}
+	return nil
+}
+
+func knownEvent(event string) bool {
+	for _, e := range WebhookEvents {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+// Webhook is a registered webhook.
+type Webhook struct {
+	ID      string            `json:"id"`
+	URL     string            `json:"url"`
+	Events  []string          `json:"events"`
+	Active  bool              `json:"active"`
+	Headers map[string]string `json:"headers,omitempty"`
+	// Secret is the signing secret. The server only returns it when the
+	// webhook is created or its secret is rotated.
+	Secret string `json:"secret,omitempty"`
+	// PreviousSecretExpiresAt is when the secret replaced by RotateSecret
+	// stops being accepted, if a grace period was requested.
+	PreviousSecretExpiresAt *time.Time `json:"previous_secret_expires_at,omitempty"`
+	CreatedAt               time.Time  `json:"created_at"`
+}
+
+// CreateWebhookTyped validates cfg and registers it as a webhook. Nothing
+// is sent when validation fails.
+func (w *WebhookOperations) CreateWebhookTyped(ctx context.Context, cfg WebhookConfig) (*Webhook, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	resp, err := w.client.makeRequest(ctx, http.MethodPost, "/webhooks", cfg, nil)
+	if err != nil {
+		return nil, err
+	}
+	var hook Webhook
+	if err := decodeMap(resp, &hook); err != nil {
+		return nil, err
+	}
+	return &hook, nil
+}
+
+// UpdateWebhook updates a webhook.
+func (w *WebhookOperations) UpdateWebhook(ctx context.Context, webhookID string, data map[string]interface{}) (map[string]interface{}, error) {
+	return w.client.makeRequest(ctx, http.MethodPut, "/webhooks/"+webhookID, data, nil)
+}
+
+// DeleteWebhook deletes a webhook.
+func (w *WebhookOperations) DeleteWebhook(ctx context.Context, webhookID string) error {
+	_, err := w.client.makeRequest(ctx, http.MethodDelete, "/webhooks/"+webhookID, nil, nil)
+	return err
+}
+
+// GetWebhookDeliveries lists recent delivery attempts for a webhook.
+func (w *WebhookOperations) GetWebhookDeliveries(ctx context.Context, webhookID string, params map[string]interface{}) (map[string]interface{}, error) {
+	return w.client.makeRequest(ctx, http.MethodGet, "/webhooks/"+webhookID+"/deliveries", nil, params)
+}
+
+// GetDelivery fetches one delivery attempt of a webhook, including the
+// request that was sent and the response the receiver returned.
+func (w *WebhookOperations) GetDelivery(ctx context.Context, webhookID, deliveryID string) (map[string]interface{}, error) {
+	return w.client.makeRequest(ctx, http.MethodGet, "/webhooks/"+webhookID+"/deliveries/"+deliveryID, nil, nil)
+}
+
+// ReplayDelivery asks the server to send a past delivery again, for
+// example after a receiver failed to process it. The response describes
+// the new delivery attempt.
+func (w *WebhookOperations) ReplayDelivery(ctx context.Context, webhookID, deliveryID string) (map[string]interface{}, error) {
+	return w.client.makeRequest(ctx, http.MethodPost, "/webhooks/"+webhookID+"/deliveries/"+deliveryID+"/replay", nil, nil)
+}
+
+// RotateSecretOption customizes RotateSecret.
+type RotateSecretOption func(*rotateSecretOptions)
+
+type rotateSecretOptions struct {
+	gracePeriod time.Duration
+}
+
+// WithGracePeriod keeps the old secret valid for d after rotation, so
+// receivers can be switched to the new secret without dropping
+// deliveries. Deliveries are signed with both secrets meanwhile.
+func WithGracePeriod(d time.Duration) RotateSecretOption {
+	return func(o *rotateSecretOptions) { o.gracePeriod = d }
+}
+
+// RotateSecret replaces a webhook's signing secret. The returned Webhook
+// carries the new secret in Secret; it is only shown this once. Without
+// WithGracePeriod the old secret stops working immediately.
+//
+// The request is never retried: a retry after a lost response would
+// rotate again and discard the secret the first attempt issued.
+func (w *WebhookOperations) RotateSecret(ctx context.Context, webhookID string, opts ...RotateSecretOption) (*Webhook, error) {
+	var o rotateSecretOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.gracePeriod < 0 {
+		return nil, fmt.Errorf("tavo: negative grace period %s", o.gracePeriod)
+	}
+	var body interface{}
+	if o.gracePeriod > 0 {
+		body = map[string]interface{}{"grace_period_seconds": int64(o.gracePeriod.Round(time.Second) / time.Second)}
+	}
+	resp, err := w.client.execute(ctx, &apiRequest{method: http.MethodPost, path: "/webhooks/" + webhookID + "/rotate-secret", body: body, noRetry: true})
+	if err != nil {
+		return nil, err
+	}
+	data, err := checkResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+	var hook Webhook
+	if err := decodeMap(data, &hook); err != nil {
+		return nil, err
+	}
+	return &hook, nil
+}
@@ -0,0 +1,232 @@
+package tavo
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseEvent(t *testing.T) {
+	var w WebhookOperations
+	body := []byte(`{"type":"scan.completed","id":"evt_1","timestamp":"2025-03-01T12:00:00Z","data":{"scan_id":"s1","findings":4}}`)
+
+	event, err := w.ParseEvent(body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if event.Type != EventScanCompleted || event.ID != "evt_1" || event.Timestamp.Hour() != 12 {
+		t.Fatalf("event = %+v", event)
+	}
+	var data struct {
+		ScanID   string `json:"scan_id"`
+		Findings int    `json:"findings"`
+	}
+	if err := event.DecodeData(&data); err != nil {
+		t.Fatal(err)
+	}
+	if data.ScanID != "s1" || data.Findings != 4 {
+		t.Fatalf("data = %+v", data)
+	}
+}
+
+func TestParseEventRejectsInvalidEnvelopes(t *testing.T) {
+	var w WebhookOperations
+	for name, body := range map[string]string{
+		"not json":          `{`,
+		"missing type":      `{"id":"e","timestamp":"2025-03-01T12:00:00Z"}`,
+		"missing id":        `{"type":"scan.failed","timestamp":"2025-03-01T12:00:00Z"}`,
+		"missing timestamp": `{"type":"scan.failed","id":"e"}`,
+	} {
+		if _, err := w.ParseEvent([]byte(body)); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+func TestVerifySignature(t *testing.T) {
+	var w WebhookOperations
+	body := []byte(`{"type":"scan.completed","id":"evt_1"}`)
+	sign := func(secret string) string {
+		mac := hmac.New(sha256.New, []byte(secret))
+		mac.Write(body)
+		return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+	}
+
+	if err := w.VerifySignature(body, sign("s3cret"), "s3cret"); err != nil {
+		t.Fatalf("valid signature: %v", err)
+	}
+	// During a grace period the header carries both signatures.
+	if err := w.VerifySignature(body, sign("old")+", "+sign("new"), "new"); err != nil {
+		t.Fatalf("rotated signature: %v", err)
+	}
+	if err := w.VerifySignature(body, sign("new"), "old", "new"); err != nil {
+		t.Fatalf("receiver with both secrets: %v", err)
+	}
+
+	for name, sig := range map[string]string{
+		"wrong secret": sign("other"),
+		"no prefix":    strings.TrimPrefix(sign("s3cret"), "sha256="),
+		"not hex":      "sha256=zz",
+		"empty":        "",
+		"truncated":    sign("s3cret")[:20],
+	} {
+		if err := w.VerifySignature(body, sig, "s3cret"); !errors.Is(err, ErrInvalidSignature) {
+			t.Errorf("%s: err = %v", name, err)
+		}
+	}
+	if err := w.VerifySignature(append(body, ' '), sign("s3cret"), "s3cret"); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("modified body: err = %v", err)
+	}
+	if err := w.VerifySignature(body, sign("")); err == nil {
+		t.Error("no secrets: expected error")
+	}
+}
+
+func TestGetAndReplayDelivery(t *testing.T) {
+	c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "GET /webhooks/wh1/deliveries/d1":
+			writeJSON(w, http.StatusOK, map[string]interface{}{
+				"id": "d1", "status": "failed",
+				"request":  map[string]interface{}{"body": `{"type":"scan.completed"}`},
+				"response": map[string]interface{}{"status": 500},
+			})
+		case "POST /webhooks/wh1/deliveries/d1/replay":
+			writeJSON(w, http.StatusAccepted, map[string]interface{}{"id": "d2", "replay_of": "d1", "status": "pending"})
+		default:
+			t.Errorf("unexpected %s %s", r.Method, r.URL.Path)
+		}
+	})
+	ctx := context.Background()
+
+	d, err := c.Webhooks().GetDelivery(ctx, "wh1", "d1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d["status"] != "failed" || d["response"].(map[string]interface{})["status"] != 500.0 {
+		t.Fatalf("delivery = %v", d)
+	}
+	replay, err := c.Webhooks().ReplayDelivery(ctx, "wh1", "d1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if replay["id"] != "d2" || replay["replay_of"] != "d1" {
+		t.Fatalf("replay = %v", replay)
+	}
+}
+
+func TestCreateWebhookTyped(t *testing.T) {
+	var sent map[string]interface{}
+	c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/webhooks" {
+			t.Errorf("unexpected %s %s", r.Method, r.URL.Path)
+		}
+		json.NewDecoder(r.Body).Decode(&sent)
+		writeJSON(w, http.StatusCreated, map[string]interface{}{
+			"id": "wh1", "url": sent["url"], "events": sent["events"], "active": true,
+			"secret": "whsec_generated", "created_at": "2025-03-01T12:00:00Z",
+		})
+	})
+
+	hook, err := c.Webhooks().CreateWebhookTyped(context.Background(), WebhookConfig{
+		URL:     "https://ci.example.com/tavo",
+		Events:  []string{EventScanCompleted, EventScanFailed},
+		Active:  true,
+		Headers: map[string]string{"X-Team": "sec"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]interface{}{
+		"url":     "https://ci.example.com/tavo",
+		"events":  []interface{}{"scan.completed", "scan.failed"},
+		"active":  true,
+		"headers": map[string]interface{}{"X-Team": "sec"},
+	}
+	if !reflect.DeepEqual(sent, want) {
+		t.Fatalf("sent %v, want %v", sent, want)
+	}
+	if hook.ID != "wh1" || hook.Secret != "whsec_generated" || !hook.Active || len(hook.Events) != 2 || hook.CreatedAt.IsZero() {
+		t.Fatalf("hook = %+v", hook)
+	}
+}
+
+func TestWebhookConfigValidate(t *testing.T) {
+	c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Error("invalid webhook sent")
+	})
+	for _, tc := range []struct {
+		cfg  WebhookConfig
+		want string
+	}{
+		{WebhookConfig{URL: "http://ci.example.com", Events: []string{EventScanCompleted}}, "must use https"},
+		{WebhookConfig{URL: "ci.example.com/hook", Events: []string{EventScanCompleted}}, "not an absolute URL"},
+		{WebhookConfig{URL: "https://ci.example.com"}, "at least one event"},
+		{WebhookConfig{URL: "https://ci.example.com", Events: []string{"scan.complete"}}, `unknown webhook event "scan.complete"`},
+	} {
+		_, err := c.Webhooks().CreateWebhookTyped(context.Background(), tc.cfg)
+		if err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("%+v: err = %v, want %q", tc.cfg, err, tc.want)
+		}
+	}
+}
+
+func TestRotateSecret(t *testing.T) {
+	var calls int
+	c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if r.Method != http.MethodPost || r.URL.Path != "/webhooks/wh1/rotate-secret" {
+			t.Errorf("unexpected %s %s", r.Method, r.URL.Path)
+		}
+		var body map[string]interface{}
+		json.NewDecoder(r.Body).Decode(&body)
+		if body["grace_period_seconds"] != float64(3600) {
+			t.Errorf("body = %v", body)
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"id": "wh1", "url": "https://example.com/hook", "events": []string{"scan.completed"},
+			"secret": "whsec_new", "previous_secret_expires_at": "2025-03-01T13:00:00Z",
+		})
+	})
+
+	hook, err := c.Webhooks().RotateSecret(context.Background(), "wh1", WithGracePeriod(time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hook.Secret != "whsec_new" || hook.PreviousSecretExpiresAt == nil ||
+		!hook.PreviousSecretExpiresAt.Equal(time.Date(2025, 3, 1, 13, 0, 0, 0, time.UTC)) {
+		t.Errorf("hook = %+v", hook)
+	}
+	if calls != 1 {
+		t.Errorf("calls = %d", calls)
+	}
+}
+
+func TestRotateSecretNoGraceNotRetried(t *testing.T) {
+	var calls int
+	c, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if r.ContentLength > 0 {
+			t.Errorf("unexpected body of %d bytes", r.ContentLength)
+		}
+		writeJSON(w, http.StatusServiceUnavailable, map[string]interface{}{"message": "try later"})
+	})
+
+	if _, err := c.Webhooks().RotateSecret(context.Background(), "wh1"); err == nil {
+		t.Fatal("expected error")
+	}
+	if calls != 1 {
+		t.Errorf("calls = %d, want 1", calls)
+	}
+	if _, err := c.Webhooks().RotateSecret(context.Background(), "wh1", WithGracePeriod(-time.Second)); err == nil {
+		t.Error("negative grace period accepted")
+	}
+}